@@ -0,0 +1,393 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asmethod defines an Analyzer that checks the As(any) bool methods of error types.
+package asmethod
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `check As(any) bool methods of error types
+
+The asmethod analysis inspects As methods of error types and reports
+  - type switches on the target that handle only one of *T and **T for an
+    error type T, so that lookups with the other form fail,
+  - unchecked type assertions on the target, which panic for other targets,
+  - returns of true on paths that never assign the target.`
+
+// Analyzer checks As(any) bool methods of error types.
+var Analyzer = &analysis.Analyzer{
+	Name:     "asmethod",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer, ctrlflow.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	cfgs, _ := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		decl, _ := n.(*ast.FuncDecl)
+
+		target, ok := asMethodTarget(pass, decl)
+		if !ok {
+			return
+		}
+
+		c := checker{pass: pass, aliases: map[types.Object]bool{target: true}}
+		c.collectAliases(decl.Body)
+		c.checkTypeSwitches(decl.Body)
+		c.checkAssertions(decl.Body)
+
+		if g := cfgs.FuncDecl(decl); g != nil {
+			c.checkReturns(g)
+		}
+	})
+
+	return nil, nil
+}
+
+// asMethodTarget returns the parameter of an As(any) bool method on an error type.
+func asMethodTarget(pass *analysis.Pass, decl *ast.FuncDecl) (types.Object, bool) {
+	if decl.Recv == nil || decl.Name.Name != "As" || decl.Body == nil {
+		return nil, false
+	}
+
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return nil, false
+	}
+
+	sig, _ := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return nil, false
+	}
+
+	param := sig.Params().At(0)
+	if iface, ok := param.Type().Underlying().(*types.Interface); !ok || !iface.Empty() {
+		return nil, false
+	}
+
+	if res, ok := sig.Results().At(0).Type().Underlying().(*types.Basic); !ok || res.Kind() != types.Bool {
+		return nil, false
+	}
+
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	if !analysisutil.ImplementsError(recv) && !analysisutil.ImplementsError(types.NewPointer(recv)) {
+		return nil, false
+	}
+
+	if param.Name() == "" || param.Name() == "_" {
+		return nil, false
+	}
+
+	return param, true
+}
+
+// checker checks a single As method.
+type checker struct {
+	pass    *analysis.Pass
+	aliases map[types.Object]bool // The target and variables derived from it by type assertions
+}
+
+// isTarget reports whether x refers to the target or one of its aliases.
+func (c *checker) isTarget(x ast.Expr) bool {
+	id, ok := ast.Unparen(x).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	return c.aliases[c.pass.TypesInfo.Uses[id]]
+}
+
+// collectAliases records the variables bound by type switches and type assertions on the target.
+func (c *checker) collectAliases(body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeSwitchStmt:
+			if _, ok := n.Assign.(*ast.AssignStmt); !ok || !c.isTarget(switchGuard(n)) {
+				break
+			}
+
+			for _, clause := range n.Body.List {
+				if obj := c.pass.TypesInfo.Implicits[clause]; obj != nil {
+					c.aliases[obj] = true
+				}
+			}
+
+		case *ast.AssignStmt:
+			if len(n.Rhs) != 1 {
+				break
+			}
+
+			if ta, ok := ast.Unparen(n.Rhs[0]).(*ast.TypeAssertExpr); ok && c.isTarget(ta.X) {
+				if id, ok := n.Lhs[0].(*ast.Ident); ok {
+					if obj := c.pass.TypesInfo.ObjectOf(id); obj != nil {
+						c.aliases[obj] = true
+					}
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// switchGuard returns the expression switched on by a type switch.
+func switchGuard(s *ast.TypeSwitchStmt) ast.Expr {
+	var x ast.Expr
+
+	switch a := s.Assign.(type) {
+	case *ast.AssignStmt:
+		x = a.Rhs[0]
+	case *ast.ExprStmt:
+		x = a.X
+	}
+
+	if ta, ok := ast.Unparen(x).(*ast.TypeAssertExpr); ok {
+		return ta.X
+	}
+
+	return nil
+}
+
+// checkTypeSwitches reports type switches on the target that handle only one of *T and **T.
+func (c *checker) checkTypeSwitches(body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		s, ok := n.(*ast.TypeSwitchStmt)
+		if !ok || !c.isTarget(switchGuard(s)) {
+			return true
+		}
+
+		handled := make(map[types.Type]ast.Expr)
+
+		var order []types.Type
+
+		for _, clause := range s.Body.List {
+			for _, expr := range clause.(*ast.CaseClause).List {
+				ptr, ok := c.pass.TypesInfo.TypeOf(expr).(*types.Pointer)
+				if !ok {
+					continue
+				}
+
+				elem := ptr.Elem()
+				if analysisutil.Alternate(elem) == nil || !analysisutil.ImplementsError(elem) {
+					continue
+				}
+
+				handled[elem] = expr
+				order = append(order, elem)
+			}
+		}
+
+		for _, elem := range order {
+			alt := analysisutil.Alternate(elem)
+			if !analysisutil.ImplementsError(alt) || hasType(handled, alt) {
+				continue
+			}
+
+			c.pass.ReportRangef(handled[elem], "As handles %s but not %s",
+				c.typeString(types.NewPointer(elem)), c.typeString(types.NewPointer(alt)))
+		}
+
+		return true
+	})
+}
+
+func hasType(handled map[types.Type]ast.Expr, t types.Type) bool {
+	for h := range handled {
+		if types.Identical(h, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkAssertions reports single-value type assertions on the target.
+func (c *checker) checkAssertions(body *ast.BlockStmt) {
+	checked := make(map[*ast.TypeAssertExpr]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		var rhs []ast.Expr
+
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 2 {
+				rhs = n.Rhs
+			}
+
+		case *ast.ValueSpec:
+			if len(n.Names) == 2 {
+				rhs = n.Values
+			}
+		}
+
+		if len(rhs) == 1 {
+			if ta, ok := ast.Unparen(rhs[0]).(*ast.TypeAssertExpr); ok {
+				checked[ta] = true
+			}
+		}
+
+		return true
+	})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if s, ok := n.(*ast.TypeSwitchStmt); ok {
+			// The guard of a type switch is not an assertion.
+			ast.Inspect(s.Body, func(n ast.Node) bool { return c.reportAssertion(n, checked) })
+
+			return false
+		}
+
+		return c.reportAssertion(n, checked)
+	})
+}
+
+func (c *checker) reportAssertion(n ast.Node, checked map[*ast.TypeAssertExpr]bool) bool {
+	if ta, ok := n.(*ast.TypeAssertExpr); ok && ta.Type != nil && !checked[ta] && c.isTarget(ta.X) {
+		c.pass.ReportRangef(ta, "unchecked type assertion on the As target panics for other targets; use the two-value form")
+	}
+
+	return true
+}
+
+// checkReturns reports returns of true on paths that never assign the target.
+func (c *checker) checkReturns(g *cfg.CFG) {
+	assigns := make([]bool, len(g.Blocks))
+	for _, b := range g.Blocks {
+		for _, n := range b.Nodes {
+			if c.assigns(n) {
+				assigns[b.Index] = true
+
+				break
+			}
+		}
+	}
+
+	preds := make([][]*cfg.Block, len(g.Blocks))
+	for _, b := range g.Blocks {
+		if !b.Live {
+			continue
+		}
+
+		for _, s := range b.Succs {
+			preds[s.Index] = append(preds[s.Index], b)
+		}
+	}
+
+	// out[b] reports whether the target is assigned on all paths from the entry to the end of b.
+	out := make([]bool, len(g.Blocks))
+	for i := range out {
+		out[i] = i != 0 || assigns[0]
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, b := range g.Blocks[1:] {
+			in := len(preds[b.Index]) > 0
+			for _, p := range preds[b.Index] {
+				in = in && out[p.Index]
+			}
+
+			if o := in || assigns[b.Index]; o != out[b.Index] {
+				out[b.Index], changed = o, true
+			}
+		}
+	}
+
+	for _, b := range g.Blocks {
+		if !b.Live {
+			continue
+		}
+
+		assigned := b.Index != 0 && len(preds[b.Index]) > 0
+		for _, p := range preds[b.Index] {
+			assigned = assigned && out[p.Index]
+		}
+
+		for _, n := range b.Nodes {
+			if ret, ok := n.(*ast.ReturnStmt); ok && !assigned && c.returnsTrue(ret) {
+				c.pass.ReportRangef(ret, "As returns true without assigning the target")
+			}
+
+			assigned = assigned || c.assigns(n)
+		}
+	}
+}
+
+// assigns reports whether n writes through the target or passes it to a function.
+func (c *checker) assigns(n ast.Node) bool {
+	found := false
+
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if star, ok := ast.Unparen(lhs).(*ast.StarExpr); ok && c.isTarget(star.X) {
+					found = true
+				}
+			}
+
+		case *ast.CallExpr:
+			for _, arg := range n.Args {
+				if c.isTarget(arg) {
+					found = true
+				}
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func (c *checker) returnsTrue(ret *ast.ReturnStmt) bool {
+	if len(ret.Results) != 1 {
+		return false
+	}
+
+	tv, ok := c.pass.TypesInfo.Types[ret.Results[0]]
+
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.Bool && constant.BoolVal(tv.Value)
+}
+
+func (c *checker) typeString(t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(c.pass.Pkg))
+}
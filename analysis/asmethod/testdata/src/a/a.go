@@ -0,0 +1,115 @@
+package a
+
+import "errors"
+
+type ValueError struct{ code int }
+
+func (ValueError) Error() string { return "value" }
+
+type PointerError struct{ code int }
+
+func (*PointerError) Error() string { return "pointer" }
+
+// OneForm handles only the value form.
+type OneForm struct{}
+
+func (OneForm) Error() string { return "one form" }
+
+func (OneForm) As(target any) bool {
+	switch t := target.(type) {
+	case *ValueError: // want `As handles \*ValueError but not \*\*ValueError`
+		*t = ValueError{1}
+		return true
+
+	case **PointerError:
+		*t = &PointerError{1}
+		return true
+	}
+
+	return false
+}
+
+// BothForms handles both forms.
+type BothForms struct{}
+
+func (BothForms) Error() string { return "both forms" }
+
+func (BothForms) As(target any) bool {
+	switch t := target.(type) {
+	case *ValueError:
+		*t = ValueError{2}
+	case **ValueError:
+		*t = &ValueError{2}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Unchecked panics for other targets.
+type Unchecked struct{}
+
+func (Unchecked) Error() string { return "unchecked" }
+
+func (Unchecked) As(target any) bool {
+	p := target.(*ValueError) // want `unchecked type assertion on the As target panics for other targets; use the two-value form`
+	*p = ValueError{3}
+
+	return true
+}
+
+// Checked uses the two-value form.
+type Checked struct{}
+
+func (Checked) Error() string { return "checked" }
+
+func (Checked) As(target any) bool {
+	if p, ok := target.(*ValueError); ok {
+		*p = ValueError{4}
+		return true
+	}
+
+	return false
+}
+
+// Lying reports success without assigning the target.
+type Lying struct{ ok bool }
+
+func (Lying) Error() string { return "lying" }
+
+func (e Lying) As(target any) bool {
+	if p, ok := target.(**PointerError); ok {
+		if e.ok {
+			return true // want `As returns true without assigning the target`
+		}
+
+		*p = &PointerError{5}
+
+		return true
+	}
+
+	return false
+}
+
+// Delegating passes the target on.
+type Delegating struct{ err error }
+
+func (e Delegating) Error() string { return "delegating" }
+
+func (e Delegating) As(target any) bool {
+	if errors.As(e.err, target) {
+		return true
+	}
+
+	return false
+}
+
+// NotAnError has an As method, but is not an error.
+type NotAnError struct{}
+
+func (NotAnError) As(target any) bool {
+	_ = target.(*ValueError)
+
+	return true
+}
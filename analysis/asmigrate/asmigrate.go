@@ -0,0 +1,511 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asmigrate defines an Analyzer that rewrites calls to errors.As into the lookups of
+// fillmore-labs.com/exp/errors.
+package asmigrate
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `suggest replacing errors.As with HasError or Has
+
+The asmigrate analysis reports calls to errors.As and suggests fixes that
+rewrite them to fillmore-labs.com/exp/errors:
+
+	var myErr *MyError
+	if errors.As(err, &myErr) { ... }
+
+becomes
+
+	if myErr, ok := errors.HasError[*MyError](err); ok { ... }
+
+when myErr is declared directly before the if statement and not used after it.
+Otherwise, the variable is kept and errors.As is replaced by AsError.
+Presence checks like errors.As(err, &MyError{}) or errors.As(err, new(MyError))
+become HasErrorType.
+
+Has, As and HasType are chosen instead when the alternate form of the target
+(pointer vs. value) also implements error.
+
+When every use of the standard errors package in a file is rewritten, its
+import is replaced by the library. Otherwise, the library is imported as
+experrors.`
+
+// Analyzer suggests replacing errors.As with HasError or Has.
+var Analyzer = &analysis.Analyzer{
+	Name:     "asmigrate",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// importName is the name used when the library has to be imported next to the standard errors package.
+const importName = "experrors"
+
+// rewrite is a planned replacement of an errors.As call.
+type rewrite struct {
+	fn    string                               // The replacement function
+	edits func(pkg string) []analysis.TextEdit // The edits, given the name the library is imported as
+}
+
+// candidate is a call to errors.As found in a file.
+type candidate struct {
+	call   *ast.CallExpr
+	target types.Type
+	stack  []ast.Node
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	var (
+		files      []*ast.File
+		candidates = make(map[*ast.File][]candidate)
+	)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		call, _ := n.(*ast.CallExpr)
+
+		lookup := analysisutil.ClassifyLookup(pass.TypesInfo, call)
+		if lookup.Kind != analysisutil.StdAs || len(stack) < 2 {
+			return true
+		}
+
+		file, _ := stack[0].(*ast.File)
+		if _, ok := candidates[file]; !ok {
+			files = append(files, file)
+		}
+
+		candidates[file] = append(candidates[file], candidate{call: call, target: lookup.Target, stack: slices.Clone(stack)})
+
+		return true
+	})
+
+	for _, file := range files {
+		migrate(pass, file, candidates[file])
+	}
+
+	return nil, nil
+}
+
+// migrate reports the calls to errors.As in file that can be rewritten, with suggested fixes.
+func migrate(pass *analysis.Pass, file *ast.File, cs []candidate) {
+	m := migrator{pass: pass}
+
+	type planned struct {
+		call *ast.CallExpr
+		rewrite
+	}
+
+	var plans []planned
+
+	for _, c := range cs {
+		if r, ok := m.plan(c.call, c.target, c.stack); ok {
+			plans = append(plans, planned{call: c.call, rewrite: r})
+		}
+	}
+
+	if len(plans) == 0 {
+		return
+	}
+
+	// When all uses of the standard errors package are rewritten, its import is removed or
+	// replaced by the library. Otherwise, the library is imported as [importName].
+	// Every fix carries the same import edits, which are merged when several fixes are applied.
+	var (
+		pkg        = importName
+		imports    []analysis.TextEdit
+		replaceStd = stdOnlyUsedByAs(pass, file) && stdUses(pass, file) == len(plans)
+	)
+
+	if name, ok := importedName(pass, file, analysisutil.ErrorsPath); ok {
+		pkg = name
+		if replaceStd {
+			imports = removeImport(file, "errors")
+		}
+	} else if spec := findImport(file, "errors"); replaceStd && spec != nil {
+		pkg, _ = importedName(pass, file, "errors")
+		imports = []analysis.TextEdit{{
+			Pos: spec.Path.Pos(), End: spec.Path.End(), NewText: []byte(strconv.Quote(analysisutil.ErrorsPath)),
+		}}
+	} else {
+		imports = addImport(file, importName, analysisutil.ErrorsPath)
+	}
+
+	for _, p := range plans {
+		edits := append(p.edits(pkg), imports...)
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     p.call.Pos(),
+			End:     p.call.End(),
+			Message: "errors.As can be replaced by " + p.fn,
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Replace errors.As with " + p.fn,
+				TextEdits: edits,
+			}},
+		})
+	}
+}
+
+// migrator plans the rewrite of errors.As calls in a file.
+type migrator struct {
+	pass *analysis.Pass
+}
+
+// plan returns the rewrite of call, which looks up target.
+func (m migrator) plan(call *ast.CallExpr, target types.Type, stack []ast.Node) (rewrite, bool) {
+	if !analysisutil.ImplementsError(target) || types.IsInterface(target) && !isNamed(target) {
+		return rewrite{}, false
+	}
+
+	names := choose(target)
+
+	switch arg := ast.Unparen(call.Args[1]).(type) {
+	case *ast.UnaryExpr:
+		if arg.Op != token.AND {
+			break
+		}
+
+		if lit, ok := ast.Unparen(arg.X).(*ast.CompositeLit); ok && len(lit.Elts) == 0 && lit.Type != nil {
+			// errors.As(err, &T{})
+			return m.presence(call, names.hasType, lit.Type)
+		}
+
+		if id, ok := ast.Unparen(arg.X).(*ast.Ident); ok {
+			if r, ok := m.declaration(call, id, names.has, stack); ok {
+				return r, true
+			}
+
+			// Keep the variable.
+			return rewrite{
+				fn: names.as,
+				edits: func(pkg string) []analysis.TextEdit {
+					return []analysis.TextEdit{{Pos: call.Fun.Pos(), End: call.Fun.End(), NewText: []byte(pkg + "." + names.as)}}
+				},
+			}, true
+		}
+
+	case *ast.CallExpr:
+		// errors.As(err, new(T))
+		if id, ok := ast.Unparen(arg.Fun).(*ast.Ident); ok && id.Name == "new" && len(arg.Args) == 1 {
+			if _, ok := m.pass.TypesInfo.Uses[id].(*types.Builtin); ok {
+				return m.presence(call, names.hasType, arg.Args[0])
+			}
+		}
+	}
+
+	return rewrite{}, false
+}
+
+// presence rewrites a presence check errors.As(err, &T{}) to fn[T](err).
+func (m migrator) presence(call *ast.CallExpr, fn string, typ ast.Expr) (rewrite, bool) {
+	text := fn + "[" + m.format(typ) + "](" + m.format(call.Args[0]) + ")"
+
+	return rewrite{
+		fn: fn,
+		edits: func(pkg string) []analysis.TextEdit {
+			return []analysis.TextEdit{{Pos: call.Pos(), End: call.End(), NewText: []byte(pkg + "." + text)}}
+		},
+	}, true
+}
+
+// declaration rewrites
+//
+//	var x T
+//	if errors.As(err, &x) {
+//
+// to
+//
+//	if x, ok := fn[T](err); ok {
+//
+// when x is not used outside of the if statement.
+func (m migrator) declaration(call *ast.CallExpr, id *ast.Ident, fn string, stack []ast.Node) (rewrite, bool) {
+	ifStmt, ok := stack[len(stack)-2].(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || ast.Unparen(ifStmt.Cond) != call {
+		return rewrite{}, false
+	}
+
+	block, ok := stack[len(stack)-3].(*ast.BlockStmt)
+	if !ok {
+		return rewrite{}, false
+	}
+
+	decl, spec, ok := precedingVar(block, ifStmt)
+	if !ok || spec.Names[0].Name != id.Name {
+		return rewrite{}, false
+	}
+
+	obj := m.pass.TypesInfo.Defs[spec.Names[0]]
+	if obj == nil || m.pass.TypesInfo.Uses[id] != obj || !m.usedOnlyWithin(obj, ifStmt) || m.capturesOK(ifStmt) {
+		return rewrite{}, false
+	}
+
+	lookup := fn + "[" + m.format(spec.Type) + "](" + m.format(call.Args[0]) + "); ok"
+
+	return rewrite{
+		fn: fn,
+		edits: func(pkg string) []analysis.TextEdit {
+			return []analysis.TextEdit{
+				{Pos: decl.Pos(), End: ifStmt.Pos()},
+				{Pos: ifStmt.Cond.Pos(), End: ifStmt.Cond.End(), NewText: []byte(id.Name + ", ok := " + pkg + "." + lookup)},
+			}
+		},
+	}, true
+}
+
+// precedingVar returns the statement "var x T" directly before stmt in block.
+func precedingVar(block *ast.BlockStmt, stmt ast.Stmt) (*ast.DeclStmt, *ast.ValueSpec, bool) {
+	for i, s := range block.List {
+		if s != stmt {
+			continue
+		}
+
+		if i == 0 {
+			break
+		}
+
+		decl, ok := block.List[i-1].(*ast.DeclStmt)
+		if !ok {
+			break
+		}
+
+		gen, ok := decl.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+			break
+		}
+
+		spec, ok := gen.Specs[0].(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Type == nil || len(spec.Values) != 0 {
+			break
+		}
+
+		return decl, spec, true
+	}
+
+	return nil, nil, false
+}
+
+// usedOnlyWithin reports whether all uses of obj are inside node.
+func (m migrator) usedOnlyWithin(obj types.Object, node ast.Node) bool {
+	for id, o := range m.pass.TypesInfo.Uses {
+		if o == obj && (id.Pos() < node.Pos() || id.Pos() >= node.End()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// capturesOK reports whether node refers to a variable named ok declared outside of it,
+// which would be shadowed by the rewrite.
+func (m migrator) capturesOK(node ast.Node) bool {
+	captures := false
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || id.Name != "ok" {
+			return !captures
+		}
+
+		if obj := m.pass.TypesInfo.Uses[id]; obj != nil && (obj.Pos() < node.Pos() || obj.Pos() >= node.End()) {
+			captures = true
+		}
+
+		return !captures
+	})
+
+	return captures
+}
+
+func (m migrator) format(node ast.Node) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, m.pass.Fset, node)
+
+	return buf.String()
+}
+
+// lookupNames are the replacement functions for a target type.
+type lookupNames struct {
+	has, as, hasType string
+}
+
+// choose returns the flexible lookups when the alternate form of target also implements error,
+// and the strict lookups otherwise.
+func choose(target types.Type) lookupNames {
+	if analysisutil.ImplementsError(analysisutil.Alternate(target)) {
+		return lookupNames{has: "Has", as: "As", hasType: "HasType"}
+	}
+
+	return lookupNames{has: "HasError", as: "AsError", hasType: "HasErrorType"}
+}
+
+func isNamed(t types.Type) bool {
+	_, ok := types.Unalias(t).(*types.Named)
+
+	return ok
+}
+
+// stdOnlyUsedByAs reports whether all references to the standard errors package in file are calls of errors.As.
+func stdOnlyUsedByAs(pass *analysis.Pass, file *ast.File) bool {
+	only := true
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || !only {
+			return only
+		}
+
+		if pkgPath(pass, sel.X) == "errors" && sel.Sel.Name != "As" {
+			only = false
+		}
+
+		return only
+	})
+
+	return only
+}
+
+// stdUses counts the references to the standard errors package in file.
+func stdUses(pass *analysis.Pass, file *ast.File) int {
+	count := 0
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && pkgPath(pass, sel.X) == "errors" {
+			count++
+		}
+
+		return true
+	})
+
+	return count
+}
+
+func pkgPath(pass *analysis.Pass, x ast.Expr) string {
+	id, ok := x.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+
+	pkg, ok := pass.TypesInfo.Uses[id].(*types.PkgName)
+	if !ok {
+		return ""
+	}
+
+	return pkg.Imported().Path()
+}
+
+// importedName returns the name path is imported under in file.
+func importedName(pass *analysis.Pass, file *ast.File, path string) (string, bool) {
+	spec := findImport(file, path)
+	if spec == nil {
+		return "", false
+	}
+
+	if spec.Name != nil {
+		return spec.Name.Name, true
+	}
+
+	if pkg, ok := pass.TypesInfo.Implicits[spec].(*types.PkgName); ok {
+		return pkg.Name(), true
+	}
+
+	return "", false
+}
+
+func findImport(file *ast.File, path string) *ast.ImportSpec {
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err == nil && p == path {
+			return spec
+		}
+	}
+
+	return nil
+}
+
+// addImport returns an edit that imports path as name.
+func addImport(file *ast.File, name, path string) []analysis.TextEdit {
+	text := name + " " + strconv.Quote(path)
+
+	var last *ast.GenDecl
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			break
+		}
+
+		if gen.Lparen.IsValid() {
+			return []analysis.TextEdit{{Pos: gen.Rparen, End: gen.Rparen, NewText: []byte("\t" + text + "\n")}}
+		}
+
+		last = gen
+	}
+
+	if last == nil {
+		return []analysis.TextEdit{{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport " + text)}}
+	}
+
+	return []analysis.TextEdit{{Pos: last.End(), End: last.End(), NewText: []byte("\nimport " + text)}}
+}
+
+// removeImport returns an edit that removes the import of path.
+func removeImport(file *ast.File, path string) []analysis.TextEdit {
+	spec := findImport(file, path)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			break
+		}
+
+		for _, s := range gen.Specs {
+			if s != spec {
+				continue
+			}
+
+			if !gen.Lparen.IsValid() {
+				return []analysis.TextEdit{{Pos: gen.Pos(), End: gen.End()}}
+			}
+
+			return []analysis.TextEdit{{Pos: spec.Pos(), End: spec.End()}}
+		}
+	}
+
+	return nil
+}
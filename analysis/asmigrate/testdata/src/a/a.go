@@ -0,0 +1,55 @@
+package a
+
+import "errors"
+
+type PointerError struct{}
+
+func (*PointerError) Error() string { return "pointer" }
+
+type ValueError struct{}
+
+func (ValueError) Error() string { return "value" }
+
+func declared(err error) {
+	var pe *PointerError
+	if errors.As(err, &pe) { // want `errors.As can be replaced by HasError`
+		println(pe)
+	}
+
+	var ve ValueError
+	if errors.As(err, &ve) { // want `errors.As can be replaced by Has`
+		println(ve.Error())
+	} else {
+		println("no ValueError")
+	}
+}
+
+func usedAfter(err error) {
+	var pe *PointerError
+	if errors.As(err, &pe) { // want `errors.As can be replaced by AsError`
+		println(pe)
+	}
+
+	println(pe)
+}
+
+func notAdjacent(err error) {
+	var ve ValueError
+
+	println("unrelated")
+
+	if !errors.As(err, &ve) { // want `errors.As can be replaced by As`
+		return
+	}
+}
+
+func shadowsOK(err error, ok bool) {
+	var pe *PointerError
+	if errors.As(err, &pe) { // want `errors.As can be replaced by AsError`
+		println(ok)
+	}
+}
+
+func presence(err error) bool {
+	return errors.As(err, &ValueError{}) || errors.As(err, new(ValueError)) // want `errors.As can be replaced by HasType` `errors.As can be replaced by HasType`
+}
@@ -0,0 +1,21 @@
+package b
+
+import (
+	"errors"
+)
+
+type PointerError struct{}
+
+func (*PointerError) Error() string { return "pointer" }
+
+var ErrSentinel = errors.New("sentinel")
+
+func lookup(err error) {
+	var pe *PointerError
+	if errors.As(err, &pe) { // want `errors.As can be replaced by HasError`
+		println(pe)
+	}
+
+	var x any
+	_ = errors.As(err, &x) // Not an error type
+}
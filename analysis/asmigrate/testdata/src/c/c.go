@@ -0,0 +1,17 @@
+package c
+
+import (
+	"errors"
+
+	xerrors "fillmore-labs.com/exp/errors"
+)
+
+type PointerError struct{}
+
+func (*PointerError) Error() string { return "pointer" }
+
+func lookup(err error) bool {
+	_, ok := xerrors.Has[*PointerError](err)
+
+	return ok || errors.As(err, new(*PointerError)) // want `errors.As can be replaced by HasErrorType`
+}
@@ -0,0 +1,14 @@
+package d
+
+import "errors"
+
+type PointerError struct{}
+
+func (*PointerError) Error() string { return "pointer" }
+
+func lookup(err error) {
+	var pe *PointerError
+	if errors.As(err, &pe) { // want `errors.As can be replaced by HasError`
+		println(pe)
+	}
+}
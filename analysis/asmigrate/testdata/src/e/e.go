@@ -0,0 +1,22 @@
+package e
+
+import "errors"
+
+type PointerError struct{}
+
+func (*PointerError) Error() string { return "pointer" }
+
+type ValueError struct{}
+
+func (ValueError) Error() string { return "value" }
+
+func first(err error) {
+	var pe *PointerError
+	if errors.As(err, &pe) { // want `errors.As can be replaced by HasError`
+		println(pe)
+	}
+}
+
+func second(err error) bool {
+	return errors.As(err, new(ValueError)) // want `errors.As can be replaced by HasType`
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asmismatch defines an Analyzer that reports error lookups whose target uses the
+// pointer or value form of a type while the type implements error with the other form.
+package asmismatch
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `report error lookups with a pointer-value mismatch in their target type
+
+The asmismatch analysis reports calls to errors.As, HasError and AsError
+where the target is a pointer *E although E implements error itself
+(with value receivers), as in
+
+	var kse *aes.KeySizeError
+	if errors.As(err, &kse) { ... } // never matches aes.KeySizeError values
+
+Such lookups usually never match, since the errors are produced in the
+other form. Use Has or As, which match both forms; for HasError and AsError,
+a suggested fix switches to them.
+
+It also reports calls to errors.As where the target is a value E although
+only *E implements error, since errors.As panics at run time for targets
+that neither implement error nor are interfaces.`
+
+// Analyzer reports error lookups with a pointer-value mismatch in their target type.
+var Analyzer = &analysis.Analyzer{
+	Name:     "asmismatch",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// replacements maps strict lookups to their flexible counterparts.
+var replacements = map[string]string{
+	"HasError": "Has",
+	"AsError":  "As",
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, _ := n.(*ast.CallExpr)
+
+		lookup := analysisutil.ClassifyLookup(pass.TypesInfo, call)
+		if lookup.Kind != analysisutil.StdAs && lookup.Kind != analysisutil.Strict {
+			return
+		}
+
+		alt, ok := mismatch(lookup.Target)
+		if !ok {
+			return
+		}
+
+		if !analysisutil.ImplementsError(lookup.Target) {
+			pass.ReportRangef(call, "errors.As panics for target %s, which does not implement error; use %s",
+				typeString(pass, lookup.Target), suggestion(pass, lookup, alt))
+
+			return
+		}
+
+		d := analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: callName(lookup) + " target " + typeString(pass, lookup.Target) + " never matches " +
+				typeString(pass, alt) + " errors; use " + suggestion(pass, lookup, alt) + " to match both forms",
+		}
+
+		if replacement, ok := replacements[lookup.Func.Name()]; ok && lookup.Kind == analysisutil.Strict {
+			id := analysisutil.FuncIdent(call)
+			d.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Replace " + lookup.Func.Name() + " with " + replacement,
+				TextEdits: []analysis.TextEdit{{Pos: id.Pos(), End: id.End(), NewText: []byte(replacement)}},
+			}}
+		}
+
+		pass.Report(d)
+	})
+
+	return nil, nil
+}
+
+// mismatch reports whether the alternate form of target is the one implementing error,
+// returning the alternate form.
+func mismatch(target types.Type) (types.Type, bool) {
+	alt := analysisutil.Alternate(target)
+	if alt == nil {
+		return nil, false
+	}
+
+	if _, ok := types.Unalias(target).(*types.Pointer); ok {
+		// *E where E implements error itself: errors are usually E values.
+		return alt, analysisutil.ImplementsError(alt)
+	}
+
+	// E where only *E implements error.
+	return alt, !analysisutil.ImplementsError(target) && analysisutil.ImplementsError(alt)
+}
+
+func callName(lookup analysisutil.Lookup) string {
+	if lookup.Kind == analysisutil.StdAs {
+		return "errors.As"
+	}
+
+	return lookup.Func.Name()
+}
+
+// suggestion returns the call that should be used instead.
+func suggestion(pass *analysis.Pass, lookup analysisutil.Lookup, alt types.Type) string {
+	switch {
+	case lookup.Func.Name() == "HasError":
+		return "Has[" + typeString(pass, lookup.Target) + "]"
+
+	case analysisutil.ImplementsError(lookup.Target):
+		return "As"
+
+	default:
+		// Only the alternate form satisfies the error constraint of Has.
+		return "Has[" + typeString(pass, alt) + "]"
+	}
+}
+
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(pass.Pkg))
+}
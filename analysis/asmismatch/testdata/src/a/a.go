@@ -0,0 +1,54 @@
+package a
+
+import (
+	"errors"
+
+	xerrors "fillmore-labs.com/exp/errors"
+)
+
+type ValueError struct{}
+
+func (ValueError) Error() string { return "value" }
+
+type PointerError struct{}
+
+func (*PointerError) Error() string { return "pointer" }
+
+func stdAs(err error) {
+	var v ValueError
+	_ = errors.As(err, &v)
+
+	var pv *ValueError
+	_ = errors.As(err, &pv) // want `errors.As target \*ValueError never matches ValueError errors; use As to match both forms`
+
+	var p PointerError
+	_ = errors.As(err, &p) // want `errors.As panics for target PointerError, which does not implement error; use Has\[\*PointerError\]`
+
+	var pp *PointerError
+	_ = errors.As(err, &pp)
+
+	var e error
+	_ = errors.As(err, &e)
+}
+
+func strict(err error) {
+	_, _ = xerrors.HasError[ValueError](err)
+	_, _ = xerrors.HasError[*ValueError](err) // want `HasError target \*ValueError never matches ValueError errors; use Has\[\*ValueError\] to match both forms`
+	_, _ = xerrors.HasError[*PointerError](err)
+
+	var pv *ValueError
+	_ = xerrors.AsError(err, &pv) // want `AsError target \*ValueError never matches ValueError errors; use As to match both forms`
+}
+
+func flexible(err error) {
+	_, _ = xerrors.Has[*ValueError](err)
+
+	var pv *ValueError
+	_ = xerrors.As(err, &pv)
+}
+
+func generic[T error](err error) {
+	var t T
+	_ = errors.As(err, &t)
+	_, _ = xerrors.HasError[T](err)
+}
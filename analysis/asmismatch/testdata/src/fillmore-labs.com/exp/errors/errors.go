@@ -0,0 +1,9 @@
+package errors
+
+func Has[T error](err error) (T, bool) { var zero T; return zero, false }
+
+func HasError[T error](err error) (T, bool) { var zero T; return zero, false }
+
+func As[T error](err error, target *T) bool { return false }
+
+func AsError[T error](err error, target *T) bool { return false }
@@ -0,0 +1,428 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Function lists the error types that may reach the error result of a function.
+type Function struct {
+	Name      string      `json:"function"`
+	Types     []ErrorType `json:"types,omitempty"`
+	Sentinels []string    `json:"sentinels,omitempty"`
+	Opaque    bool        `json:"opaque,omitempty"` // Errors from dynamic calls, parameters or fields
+}
+
+// ErrorType is a concrete error type returned by a function.
+type ErrorType struct {
+	Type    string `json:"type"`
+	Form    string `json:"form"`    // "pointer" or "value"
+	Wrapped bool   `json:"wrapped"` // Only returned wrapped by another error
+}
+
+var errLoad = errors.New("errors while loading packages")
+
+// inventory loads the packages matching patterns in dir and lists the error types of their exported functions.
+func inventory(dir string, patterns ...string) ([]Function, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedModule,
+		Dir: dir,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, errLoad
+	}
+
+	prog, ssapkgs := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+
+	// Only build code outside the standard library; calls into it, except for the known
+	// wrappers, are opaque.
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		if p.Module != nil {
+			if pkg := prog.Package(p.Types); pkg != nil {
+				pkg.Build()
+			}
+		}
+	})
+
+	inv := inventorist{prog: prog, results: make(map[funcResult]*typeSet)}
+
+	var functions []Function
+
+	for _, pkg := range ssapkgs {
+		if pkg == nil {
+			continue
+		}
+
+		for _, fn := range exportedFuncs(prog, pkg) {
+			for i := range fn.Signature.Results().Len() {
+				if !isError(fn.Signature.Results().At(i).Type()) {
+					continue
+				}
+
+				functions = append(functions, inv.result(fn, i).function(fn.String()))
+			}
+		}
+	}
+
+	return functions, nil
+}
+
+// exportedFuncs returns the exported functions and methods of exported types in pkg, sorted by name.
+func exportedFuncs(prog *ssa.Program, pkg *ssa.Package) []*ssa.Function {
+	var funcs []*ssa.Function
+
+	for _, member := range pkg.Members {
+		switch m := member.(type) {
+		case *ssa.Function:
+			if token.IsExported(m.Name()) && m.TypeParams().Len() == 0 {
+				funcs = append(funcs, m)
+			}
+
+		case *ssa.Type:
+			named, ok := m.Type().(*types.Named)
+			if !ok || !token.IsExported(m.Name()) || named.TypeParams().Len() > 0 {
+				continue
+			}
+
+			for _, t := range []types.Type{named, types.NewPointer(named)} {
+				mset := prog.MethodSets.MethodSet(t)
+				for i := range mset.Len() {
+					if fn := prog.MethodValue(mset.At(i)); fn != nil && token.IsExported(fn.Name()) && !slices.Contains(funcs, fn) {
+						funcs = append(funcs, fn)
+					}
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(funcs, func(a, b *ssa.Function) int { return strings.Compare(a.String(), b.String()) })
+
+	return funcs
+}
+
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// typeSet collects the errors that may reach a value.
+type typeSet struct {
+	types     map[string]*foundType
+	sentinels map[string]bool
+	opaque    bool
+}
+
+type foundType struct {
+	typ             types.Type
+	direct, wrapped bool
+}
+
+func newTypeSet() *typeSet {
+	return &typeSet{types: make(map[string]*foundType), sentinels: make(map[string]bool)}
+}
+
+func (s *typeSet) add(t types.Type, wrapped bool) {
+	key := types.TypeString(t, nil)
+
+	f, ok := s.types[key]
+	if !ok {
+		f = &foundType{typ: t}
+		s.types[key] = f
+	}
+
+	if wrapped {
+		f.wrapped = true
+	} else {
+		f.direct = true
+	}
+}
+
+func (s *typeSet) merge(o *typeSet, wrapped bool) {
+	for _, f := range o.types {
+		if f.direct {
+			s.add(f.typ, wrapped)
+		}
+
+		if f.wrapped {
+			s.add(f.typ, true)
+		}
+	}
+
+	for name := range o.sentinels {
+		s.sentinels[name] = true
+	}
+
+	s.opaque = s.opaque || o.opaque
+}
+
+func (s *typeSet) function(name string) Function {
+	f := Function{Name: name, Opaque: s.opaque}
+
+	for key, t := range s.types {
+		form := "value"
+		if _, ok := t.typ.Underlying().(*types.Pointer); ok {
+			form = "pointer"
+		}
+
+		f.Types = append(f.Types, ErrorType{Type: key, Form: form, Wrapped: !t.direct})
+	}
+
+	slices.SortFunc(f.Types, func(a, b ErrorType) int { return strings.Compare(a.Type, b.Type) })
+
+	for name := range s.sentinels {
+		f.Sentinels = append(f.Sentinels, name)
+	}
+
+	slices.Sort(f.Sentinels)
+
+	return f
+}
+
+// funcResult identifies a result of a function.
+type funcResult struct {
+	fn    *ssa.Function
+	index int
+}
+
+// inventorist computes the errors reaching function results, memoized.
+type inventorist struct {
+	prog    *ssa.Program
+	results map[funcResult]*typeSet
+}
+
+// result returns the errors that may reach result i of fn.
+func (inv *inventorist) result(fn *ssa.Function, i int) *typeSet {
+	key := funcResult{fn: fn, index: i}
+	if s, ok := inv.results[key]; ok {
+		return s // Possibly incomplete for recursive functions
+	}
+
+	s := newTypeSet()
+	inv.results[key] = s
+
+	if fn.Blocks == nil {
+		s.opaque = true
+
+		return s
+	}
+
+	for _, b := range fn.Blocks {
+		if ret, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return); ok && i < len(ret.Results) {
+			inv.value(ret.Results[i], s, false, make(map[ssa.Value]bool))
+		}
+	}
+
+	return s
+}
+
+// value adds the errors that may reach v to s.
+func (inv *inventorist) value(v ssa.Value, s *typeSet, wrapped bool, visited map[ssa.Value]bool) {
+	if visited[v] {
+		return
+	}
+
+	visited[v] = true
+
+	switch v := v.(type) {
+	case *ssa.MakeInterface:
+		if types.Implements(v.X.Type(), types.Universe.Lookup("error").Type().Underlying().(*types.Interface)) {
+			s.add(v.X.Type(), wrapped)
+		}
+
+	case *ssa.Const:
+		// nil
+
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			inv.value(edge, s, wrapped, visited)
+		}
+
+	case *ssa.ChangeInterface:
+		inv.value(v.X, s, wrapped, visited)
+
+	case *ssa.Call:
+		inv.call(&v.Call, 0, s, wrapped, visited)
+
+	case *ssa.Extract:
+		if call, ok := v.Tuple.(*ssa.Call); ok {
+			inv.call(&call.Call, v.Index, s, wrapped, visited)
+		} else {
+			s.opaque = true
+		}
+
+	case *ssa.UnOp:
+		if g, ok := v.X.(*ssa.Global); ok && v.Op == token.MUL {
+			s.sentinels[g.Pkg.Pkg.Path()+"."+g.Name()] = true
+		} else {
+			s.opaque = true
+		}
+
+	default:
+		s.opaque = true
+	}
+}
+
+// call adds the errors that may reach result i of a call to s.
+func (inv *inventorist) call(c *ssa.CallCommon, i int, s *typeSet, wrapped bool, visited map[ssa.Value]bool) {
+	callee := c.StaticCallee()
+	if callee == nil {
+		s.opaque = true
+
+		return
+	}
+
+	switch name := qualifiedName(callee); name {
+	case "errors.New":
+		// Opaque sentinel or message, not matchable by type
+
+	case "fmt.Errorf":
+		format, ok := c.Args[0].(*ssa.Const)
+		if !ok || format.Value == nil || format.Value.Kind() != constant.String {
+			s.opaque = true
+
+			return
+		}
+
+		args := variadicArgs(c.Args[1])
+		for _, n := range wrappedArgs(constant.StringVal(format.Value)) {
+			if arg, ok := args[n]; ok {
+				inv.value(arg, s, true, visited)
+			}
+		}
+
+	case "errors.Join":
+		for _, arg := range variadicArgs(c.Args[0]) {
+			inv.value(arg, s, true, visited)
+		}
+
+	default:
+		s.merge(inv.result(callee, i), wrapped)
+	}
+}
+
+func qualifiedName(fn *ssa.Function) string {
+	if fn.Pkg == nil || fn.Signature.Recv() != nil {
+		return ""
+	}
+
+	return fn.Pkg.Pkg.Path() + "." + fn.Name()
+}
+
+// variadicArgs returns the elements stored into the slice passed as variadic arguments, by index.
+func variadicArgs(v ssa.Value) map[int]ssa.Value {
+	args := make(map[int]ssa.Value)
+
+	slice, ok := v.(*ssa.Slice)
+	if !ok {
+		return args
+	}
+
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok {
+		return args
+	}
+
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+
+		index, ok := addr.Index.(*ssa.Const)
+		if !ok {
+			continue
+		}
+
+		for _, ref := range *addr.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+				args[int(index.Int64())] = store.Val
+			}
+		}
+	}
+
+	return args
+}
+
+// wrappedArgs returns the indices of the arguments formatted with %w.
+func wrappedArgs(format string) []int {
+	var wrapped []int
+
+	arg := 0
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		for i++; i < len(format); i++ {
+			c := format[i]
+
+			switch {
+			case c == '%' && format[i-1] == '%':
+				// Literal percent sign
+
+			case c == '*':
+				arg++
+
+				continue
+
+			case c == '[':
+				var n int
+				if _, err := fmt.Sscanf(format[i:], "[%d]", &n); err == nil {
+					arg = n - 1
+				}
+
+				if end := strings.IndexByte(format[i:], ']'); end >= 0 {
+					i += end
+				}
+
+				continue
+
+			case strings.IndexByte("+-# 0123456789.", c) >= 0:
+				continue
+
+			default:
+				if c == 'w' {
+					wrapped = append(wrapped, arg)
+				}
+
+				arg++
+			}
+
+			break
+		}
+	}
+
+	return wrapped
+}
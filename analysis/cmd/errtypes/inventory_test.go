@@ -0,0 +1,58 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestInventory(t *testing.T) {
+	t.Parallel()
+
+	functions, err := inventory("testdata/example", "./lib")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeText(&buf, functions); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	const expected = `(*example.com/example/lib.Client).Do
+	example.com/example/lib.ErrSentinel (sentinel)
+example.com/example/lib.Direct
+	*example.com/example/lib.PointerError (pointer)
+example.com/example/lib.Indirect
+	*example.com/example/lib.PointerError (pointer, wrapped)
+	example.com/example/lib.ValueError (value)
+example.com/example/lib.Joined
+	*example.com/example/lib.PointerError (pointer, wrapped)
+	example.com/example/lib.ErrSentinel (sentinel)
+example.com/example/lib.Opaque
+	... (opaque)
+example.com/example/lib.Value
+	example.com/example/lib.ValueError (value)
+example.com/example/lib.Wrapped
+	example.com/example/lib.ValueError (value, wrapped)
+`
+
+	if got := buf.String(); got != expected {
+		t.Errorf("Expected output\n%s\nbut got\n%s", expected, got)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command errtypes lists the error types that may reach the error results of exported functions.
+//
+// Usage:
+//
+//	errtypes [-json] packages...
+//
+// For each exported function and method, errtypes prints the concrete error types returned directly
+// or wrapped by fmt.Errorf with %w, errors.Join or functions in the analyzed code, noting whether
+// they are returned as pointers or values. Sentinel variables are listed separately. Functions that
+// also return errors from dynamic calls, parameters, fields or the standard library are marked as opaque.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	jsonOutput := flag.Bool("json", false, "print JSON output")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-json] packages...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	functions, err := inventory("", flag.Args()...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *jsonOutput {
+		err = writeJSON(os.Stdout, functions)
+	} else {
+		err = writeText(os.Stdout, functions)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func writeJSON(w io.Writer, functions []Function) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(functions)
+}
+
+func writeText(w io.Writer, functions []Function) error {
+	for _, f := range functions {
+		if _, err := fmt.Fprintln(w, f.Name); err != nil {
+			return err
+		}
+
+		for _, t := range f.Types {
+			note := t.Form
+			if t.Wrapped {
+				note += ", wrapped"
+			}
+
+			if _, err := fmt.Fprintf(w, "\t%s (%s)\n", t.Type, note); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range f.Sentinels {
+			if _, err := fmt.Fprintf(w, "\t%s (sentinel)\n", s); err != nil {
+				return err
+			}
+		}
+
+		if f.Opaque {
+			if _, err := fmt.Fprintln(w, "\t... (opaque)"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+)
+
+type PointerError struct{}
+
+func (*PointerError) Error() string { return "pointer" }
+
+type ValueError struct{ Code int }
+
+func (ValueError) Error() string { return "value" }
+
+var ErrSentinel = errors.New("sentinel")
+
+func Direct(fail bool) error {
+	if fail {
+		return &PointerError{}
+	}
+
+	return nil
+}
+
+func Value() (int, error) { return 0, ValueError{Code: 1} }
+
+func Wrapped() error {
+	return fmt.Errorf("context %d: %w", 1, ValueError{Code: 2})
+}
+
+func Joined() error {
+	return errors.Join(Direct(true), ErrSentinel)
+}
+
+func Indirect(fail bool) error {
+	if err := Direct(fail); err != nil {
+		return fmt.Errorf("indirect: %w", err)
+	}
+
+	_, err := Value()
+
+	return err
+}
+
+func Opaque(err error) error { return err }
+
+type Client struct{}
+
+func (*Client) Do() error { return ErrSentinel }
+
+func unexported() error { return &PointerError{} }
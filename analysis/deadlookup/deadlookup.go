@@ -0,0 +1,307 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deadlookup defines an Analyzer that reports error lookups in if/else chains and switches
+// that are subsumed by an earlier lookup.
+package deadlookup
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `report unreachable branches in cascades of error lookups
+
+The deadlookup analysis reports checks with Has, HasError, As, AsError and
+errors.As in if/else chains and switches that can never succeed, because
+every error they match is already matched by an earlier check on the same
+error:
+
+	if _, ok := errors.Has[net.Error](err); ok {
+		...
+	} else if _, ok := errors.Has[*net.OpError](err); ok { // unreachable
+		...
+	}
+
+A check is subsumed when the earlier checks together look for an
+interface the target implements, for the same type, or - with Has and As -
+for the pointer-value alternate of the target. Since Has skips nil
+pointers when matching alternates, Has[T] does not subsume a check for *T,
+which matches (*T)(nil). As methods of errors in the tree are not
+considered.`
+
+// Analyzer reports unreachable branches in cascades of error lookups.
+var Analyzer = &analysis.Analyzer{
+	Name:     "deadlookup",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// check is an error lookup used as a branch condition.
+type check struct {
+	call   *ast.CallExpr
+	lookup analysisutil.Lookup
+	err    string // Identifies the error looked up
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+		(*ast.SwitchStmt)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		var checks []check
+
+		switch n := n.(type) {
+		case *ast.IfStmt:
+			if parent, ok := stack[len(stack)-2].(*ast.IfStmt); ok && parent.Else == n {
+				return true // Part of an enclosing chain
+			}
+
+			for s := n; s != nil; s, _ = s.Else.(*ast.IfStmt) {
+				if c, ok := ifCheck(pass, s); ok {
+					checks = append(checks, c)
+				}
+			}
+
+		case *ast.SwitchStmt:
+			if n.Tag != nil {
+				break
+			}
+
+			for _, clause := range n.Body.List {
+				for _, expr := range clause.(*ast.CaseClause).List {
+					if c, ok := callCheck(pass, expr); ok {
+						checks = append(checks, c)
+					}
+				}
+			}
+		}
+
+		report(pass, checks)
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// report reports the checks subsumed by the earlier ones.
+func report(pass *analysis.Pass, checks []check) {
+	for i, later := range checks {
+		covering, ok := cover(checks[:i], later)
+		if !ok {
+			continue
+		}
+
+		targets := make([]string, len(covering))
+		for j, c := range covering {
+			targets[j] = typeString(pass, c.lookup.Target)
+		}
+
+		handled := "the earlier check for " + targets[0]
+		if len(targets) > 1 {
+			handled = "the earlier checks for " + strings.Join(targets, " and ")
+		}
+
+		pass.ReportRangef(later.call, "check for %s is unreachable: matching errors are handled by %s",
+			typeString(pass, later.lookup.Target), handled)
+	}
+}
+
+// cover returns the earlier checks that together match every error matched by later,
+// preferring a single check.
+func cover(earlier []check, later check) ([]check, bool) {
+	laterForms := forms(later.lookup)
+
+	covers := func(c check, f types.Type) bool {
+		return matches(c.lookup, f, types.Identical(f, later.lookup.Target))
+	}
+
+	var covering []check // Earlier checks matching some form of later
+
+	for _, c := range earlier {
+		if c.err != later.err || !slices.ContainsFunc(laterForms, func(f types.Type) bool { return covers(c, f) }) {
+			continue
+		}
+
+		if !slices.ContainsFunc(laterForms, func(f types.Type) bool { return !covers(c, f) }) {
+			return []check{c}, true
+		}
+
+		covering = append(covering, c)
+	}
+
+	// Keep the first check for each form.
+	var union []check
+
+	for _, f := range laterForms {
+		i := slices.IndexFunc(covering, func(c check) bool { return covers(c, f) })
+		if i < 0 {
+			return nil, false
+		}
+
+		if !slices.ContainsFunc(union, func(c check) bool { return c.call == covering[i].call }) {
+			union = append(union, covering[i])
+		}
+	}
+
+	return union, true
+}
+
+// ifCheck returns the lookup of
+//
+//	if errors.As(err, &target) { ...
+//
+// or
+//
+//	if _, ok := Has[T](err); ok { ...
+func ifCheck(pass *analysis.Pass, s *ast.IfStmt) (check, bool) {
+	if s.Init == nil {
+		return callCheck(pass, s.Cond)
+	}
+
+	assign, ok := s.Init.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return check{}, false
+	}
+
+	cond, ok := ast.Unparen(s.Cond).(*ast.Ident)
+	if !ok {
+		return check{}, false
+	}
+
+	okVar, ok := assign.Lhs[1].(*ast.Ident)
+	if !ok || pass.TypesInfo.ObjectOf(okVar) != pass.TypesInfo.ObjectOf(cond) {
+		return check{}, false
+	}
+
+	return lookupCheck(pass, assign.Rhs[0])
+}
+
+// callCheck returns the lookup of a boolean condition like errors.As(err, &target).
+func callCheck(pass *analysis.Pass, cond ast.Expr) (check, bool) {
+	c, ok := lookupCheck(pass, cond)
+	if !ok || len(c.call.Args) != 2 {
+		return check{}, false
+	}
+
+	return c, true
+}
+
+func lookupCheck(pass *analysis.Pass, x ast.Expr) (check, bool) {
+	call, ok := ast.Unparen(x).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return check{}, false
+	}
+
+	lookup := analysisutil.ClassifyLookup(pass.TypesInfo, call)
+	if lookup.Kind == analysisutil.NoLookup {
+		return check{}, false
+	}
+
+	if _, ok := types.Unalias(lookup.Target).(*types.TypeParam); ok {
+		return check{}, false
+	}
+
+	err, ok := errKey(pass.TypesInfo, call.Args[0])
+	if !ok {
+		return check{}, false
+	}
+
+	return check{call: call, lookup: lookup, err: err}, true
+}
+
+// errKey identifies err expressions consisting of variables and field selections.
+func errKey(info *types.Info, x ast.Expr) (string, bool) {
+	root := ast.Unparen(x)
+	for {
+		sel, ok := root.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		root = ast.Unparen(sel.X)
+	}
+
+	id, ok := root.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+
+	obj, ok := info.ObjectOf(id).(*types.Var)
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf("%p:%s", obj, types.ExprString(x)), true
+}
+
+// matches reports whether lookup matches an error of type t. When direct is set, t is the target of
+// the later check, which also matches nil pointers of type t.
+func matches(lookup analysisutil.Lookup, t types.Type, direct bool) bool {
+	if types.IsInterface(lookup.Target) {
+		return types.Implements(t, lookup.Target.Underlying().(*types.Interface))
+	}
+
+	if types.Identical(lookup.Target, t) {
+		return true
+	}
+
+	if _, ok := types.Unalias(t).(*types.Pointer); ok && direct {
+		// Has skips nil pointers when matching the alternate form, like (*T)(nil) for Has[T].
+		return false
+	}
+
+	forms := forms(lookup)
+
+	return len(forms) > 1 && types.Identical(forms[1], t)
+}
+
+// forms returns the error types matched by lookup.
+func forms(lookup analysisutil.Lookup) []types.Type {
+	if lookup.Kind != analysisutil.Flexible {
+		return []types.Type{lookup.Target}
+	}
+
+	if alt := analysisutil.Alternate(lookup.Target); analysisutil.ImplementsError(alt) {
+		return []types.Type{lookup.Target, alt}
+	}
+
+	return []types.Type{lookup.Target}
+}
+
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(pass.Pkg))
+}
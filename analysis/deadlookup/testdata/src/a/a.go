@@ -0,0 +1,73 @@
+package a
+
+import (
+	stderrors "errors"
+
+	"fillmore-labs.com/exp/errors"
+)
+
+type TimeoutError interface {
+	error
+	Timeout() bool
+}
+
+type OpError struct{}
+
+func (*OpError) Error() string { return "op" }
+func (*OpError) Timeout() bool { return false }
+
+type ValueError struct{}
+
+func (ValueError) Error() string { return "value" }
+
+func chains(err, other error) {
+	if _, ok := errors.Has[TimeoutError](err); ok {
+		println("timeout")
+	} else if _, ok := errors.Has[*OpError](err); ok { // want `check for \*OpError is unreachable: matching errors are handled by the earlier check for TimeoutError`
+		println("op")
+	}
+
+	if _, ok := errors.Has[TimeoutError](err); ok {
+		println("timeout")
+	} else if _, ok := errors.Has[*OpError](other); ok {
+		println("op on another error")
+	}
+
+	if _, ok := errors.Has[ValueError](err); ok {
+		println("value")
+	} else if _, ok := errors.HasError[*ValueError](err); ok {
+		println("pointer, reachable for (*ValueError)(nil)")
+	}
+
+	if _, ok := errors.HasError[ValueError](err); ok {
+		println("value")
+	} else if _, ok := errors.HasError[*ValueError](err); ok {
+		println("pointer, reachable with strict lookups")
+	} else if _, ok := errors.Has[ValueError](err); ok { // want `check for ValueError is unreachable: matching errors are handled by the earlier checks for ValueError and \*ValueError`
+		println("both forms already handled")
+	}
+
+	var op *OpError
+	if _, ok := errors.Has[TimeoutError](err); ok {
+		println("timeout")
+	} else if stderrors.As(err, &op) { // want `check for \*OpError is unreachable`
+		println("op")
+	}
+}
+
+func switches(err error) {
+	var (
+		t  TimeoutError
+		op *OpError
+		ve ValueError
+	)
+
+	switch {
+	case errors.As(err, &t):
+		println("timeout")
+	case errors.AsError(err, &ve):
+		println("value")
+	case errors.AsError(err, &op): // want `check for \*OpError is unreachable: matching errors are handled by the earlier check for TimeoutError`
+		println("op")
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package errorform defines an Analyzer that reports error types converted to error in both
+// their pointer and value form.
+package errorform
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `report error types converted to error as both T and *T
+
+When an error type T has a value receiver Error method, both T and *T
+implement error. Returning T in some places and *T in others makes
+errors.As lookups fail, depending on the form requested.
+
+The errorform analysis reports such types, across packages. The intended
+form can be declared with a directive in the type's documentation:
+
+	//errors:pointer
+	type MyError struct{ ... }
+
+after which conversions of the other form are reported.`
+
+// Analyzer reports error types converted to error as both T and *T.
+var Analyzer = &analysis.Analyzer{
+	Name:      "errorform",
+	Doc:       Doc,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{(*formFact)(nil), (*conversionsFact)(nil)},
+}
+
+// form is the pointer or value form of an error type.
+type form int
+
+const (
+	valueForm form = iota
+	pointerForm
+)
+
+func (f form) directive() string {
+	if f == pointerForm {
+		return directivePointer
+	}
+
+	return directiveValue
+}
+
+const (
+	directivePointer = "//errors:pointer"
+	directiveValue   = "//errors:value"
+)
+
+// formFact records the declared form of an error type.
+type formFact struct {
+	Form form
+}
+
+func (*formFact) AFact() {}
+
+func (f *formFact) String() string { return f.Form.directive() }
+
+// conversionsFact counts the conversions to error of imported and local types in a package, by form.
+type conversionsFact struct {
+	Counts map[string][2]int // Key is the qualified type name
+}
+
+func (*conversionsFact) AFact() {}
+
+func (f *conversionsFact) String() string { return "conversions" }
+
+// conversion is a conversion of an error type to error.
+type conversion struct {
+	expr ast.Expr
+	name *types.TypeName
+	form form
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	declared := collectDirectives(pass, inspect)
+
+	var conversions []conversion
+
+	counts := make(map[string][2]int)
+
+	analysisutil.ForEachConversion(inspect, pass.TypesInfo, func(expr ast.Expr, to types.Type) {
+		if !analysisutil.ImplementsError(to) {
+			return
+		}
+
+		name, f, ok := errorForm(pass.TypesInfo.TypeOf(expr))
+		if !ok {
+			return
+		}
+
+		conversions = append(conversions, conversion{expr: expr, name: name, form: f})
+
+		c := counts[key(name)]
+		c[f]++
+		counts[key(name)] = c
+	})
+
+	if len(counts) > 0 {
+		pass.ExportPackageFact(&conversionsFact{Counts: counts})
+	}
+
+	totals := make(map[string][2]int, len(counts))
+	for k, c := range counts {
+		totals[k] = c
+	}
+
+	for _, fact := range pass.AllPackageFacts() {
+		cf, ok := fact.Fact.(*conversionsFact)
+		if !ok || fact.Package == pass.Pkg {
+			continue
+		}
+
+		for k, c := range cf.Counts {
+			if t, ok := totals[k]; ok {
+				totals[k] = [2]int{t[0] + c[0], t[1] + c[1]}
+			}
+		}
+	}
+
+	for _, c := range conversions {
+		if f, ok := declaredForm(pass, declared, c.name); ok {
+			if c.form != f {
+				pass.ReportRangef(c.expr, "%s converted to error as %s, but declared %s",
+					c.name.Name(), formString(c.name, c.form), f.directive())
+			}
+
+			continue
+		}
+
+		t := totals[key(c.name)]
+		if t[valueForm] == 0 || t[pointerForm] == 0 || t[c.form] > t[1-c.form] {
+			continue
+		}
+
+		pass.ReportRangef(c.expr, "%s converted to error as %s, elsewhere as %s; declare the intended form with %s or %s",
+			c.name.Name(), formString(c.name, c.form), formString(c.name, 1-c.form), directiveValue, directivePointer)
+	}
+
+	return nil, nil
+}
+
+// collectDirectives exports the declared forms of error types in the package.
+func collectDirectives(pass *analysis.Pass, inspect *inspector.Inspector) map[*types.TypeName]form {
+	declared := make(map[*types.TypeName]form)
+
+	nodeFilter := []ast.Node{
+		(*ast.GenDecl)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		decl, _ := n.(*ast.GenDecl)
+
+		for _, spec := range decl.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			doc := ts.Doc
+			if doc == nil && len(decl.Specs) == 1 {
+				doc = decl.Doc
+			}
+
+			f, ok := directive(doc)
+			if !ok {
+				continue
+			}
+
+			name, ok := pass.TypesInfo.Defs[ts.Name].(*types.TypeName)
+			if !ok {
+				continue
+			}
+
+			if _, _, ok := errorForm(name.Type()); !ok {
+				pass.ReportRangef(ts.Name, "%s has no effect: %s is not an error in both forms", f.directive(), name.Name())
+
+				continue
+			}
+
+			declared[name] = f
+			pass.ExportObjectFact(name, &formFact{Form: f})
+		}
+	})
+
+	return declared
+}
+
+// directive returns the form declared in doc.
+func directive(doc *ast.CommentGroup) (form, bool) {
+	if doc == nil {
+		return 0, false
+	}
+
+	for _, c := range doc.List {
+		switch strings.TrimSpace(c.Text) {
+		case directivePointer:
+			return pointerForm, true
+
+		case directiveValue:
+			return valueForm, true
+		}
+	}
+
+	return 0, false
+}
+
+func declaredForm(pass *analysis.Pass, declared map[*types.TypeName]form, name *types.TypeName) (form, bool) {
+	if f, ok := declared[name]; ok {
+		return f, true
+	}
+
+	var fact formFact
+	if pass.ImportObjectFact(name, &fact) {
+		return fact.Form, true
+	}
+
+	return 0, false
+}
+
+// errorForm returns the package-level type name and form of t, when both forms implement error.
+func errorForm(t types.Type) (*types.TypeName, form, bool) {
+	f := valueForm
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t, f = ptr.Elem(), pointerForm
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || types.IsInterface(named) || !analysisutil.ImplementsError(named) {
+		return nil, 0, false
+	}
+
+	name := named.Origin().Obj()
+	if name.Pkg() == nil || name.Parent() != name.Pkg().Scope() {
+		return nil, 0, false
+	}
+
+	return name, f, true
+}
+
+func key(name *types.TypeName) string {
+	return name.Pkg().Path() + "." + name.Name()
+}
+
+func formString(name *types.TypeName, f form) string {
+	if f == pointerForm {
+		return "*" + name.Name()
+	}
+
+	return name.Name()
+}
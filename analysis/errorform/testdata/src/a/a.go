@@ -0,0 +1,47 @@
+package a // want package:"conversions"
+
+type MixedError struct{}
+
+func (MixedError) Error() string { return "mixed" }
+
+func Value() error { return MixedError{} }
+
+func Value2() error { return MixedError{} }
+
+func Pointer() error {
+	return &MixedError{} // want `MixedError converted to error as \*MixedError, elsewhere as MixedError; declare the intended form with //errors:value or //errors:pointer`
+}
+
+// PointerError is always a pointer.
+//
+//errors:pointer
+type PointerError struct{} // want PointerError:"//errors:pointer"
+
+func (PointerError) Error() string { return "pointer" }
+
+func NewPointer() error { return &PointerError{} }
+
+func Errors() []error {
+	return []error{
+		PointerError{}, // want `PointerError converted to error as PointerError, but declared //errors:pointer`
+		&PointerError{},
+	}
+}
+
+// CrossError is converted as a value here and as a pointer in b.
+type CrossError struct{}
+
+func (CrossError) Error() string { return "cross" }
+
+func Cross() error {
+	var err error = CrossError{}
+
+	return err
+}
+
+// PointerReceiverError can only be used as a pointer.
+//
+//errors:value
+type PointerReceiverError struct{} // want `//errors:value has no effect: PointerReceiverError is not an error in both forms`
+
+func (*PointerReceiverError) Error() string { return "pointer receiver" }
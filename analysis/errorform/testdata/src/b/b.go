@@ -0,0 +1,17 @@
+package b // want package:"conversions"
+
+import "a"
+
+func Cross() error {
+	return &a.CrossError{} // want `CrossError converted to error as \*CrossError, elsewhere as CrossError; declare the intended form with //errors:value or //errors:pointer`
+}
+
+func Check(err error) {
+	Report(a.PointerError{}) // want `PointerError converted to error as PointerError, but declared //errors:pointer`
+	Report(&a.PointerError{})
+}
+
+func Report(errs ...error) {
+	ch := make(chan error, 1)
+	ch <- a.MixedError{}
+}
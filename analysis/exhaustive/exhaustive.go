@@ -0,0 +1,288 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package exhaustive defines an Analyzer that checks error classifications marked with an
+// //errors:exhaustive directive for completeness.
+package exhaustive
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `check that error classifications cover all error types
+
+A type switch, builder call or registry literal marked with the directive
+
+	//errors:exhaustive example.com/pkg example.com/other
+
+must cover every error type declared in the listed packages (the current
+package when none are listed). For a type switch, the case types count;
+otherwise, the type arguments of generic calls and the types of error
+composite literals in the marked statement or declaration:
+
+	//errors:exhaustive example.com/pkg
+	var statuses = map[errors.SetType]int{
+		errors.SetTypeOf[*pkg.NotFoundError](): http.StatusNotFound,
+		...
+	}
+
+A type is covered by an interface it implements. Type arguments also cover
+the pointer-value alternate of the type, as Has defines it.`
+
+// Analyzer checks that error classifications cover all error types.
+var Analyzer = &analysis.Analyzer{
+	Name: "exhaustive",
+	Doc:  Doc,
+	Run:  run,
+}
+
+const directive = "//errors:exhaustive"
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				paths, ok := parseDirective(c.Text)
+				if !ok {
+					continue
+				}
+
+				line := pass.Fset.Position(group.End()).Line + 1
+
+				node := markedNode(pass.Fset, file, line)
+				if node == nil {
+					pass.ReportRangef(c, "%s directive must precede a statement or declaration", directive)
+
+					continue
+				}
+
+				check(pass, node, paths)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// parseDirective returns the package paths listed in an //errors:exhaustive comment.
+func parseDirective(text string) ([]string, bool) {
+	rest, ok := strings.CutPrefix(text, directive)
+	if !ok || rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, false
+	}
+
+	return strings.Fields(rest), true
+}
+
+// markedNode returns the outermost statement or declaration starting at line.
+func markedNode(fset *token.FileSet, file *ast.File, line int) ast.Node {
+	var found ast.Node
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if found != nil || n == nil {
+			return false
+		}
+
+		switch n.(type) {
+		case ast.Stmt, ast.Decl:
+			if fset.Position(n.Pos()).Line == line {
+				found = n
+
+				return false
+			}
+		}
+
+		start, end := fset.Position(n.Pos()).Line, fset.Position(n.End()).Line
+
+		return start <= line && line <= end
+	})
+
+	return found
+}
+
+// check reports the error types of the listed packages not covered by node.
+func check(pass *analysis.Pass, node ast.Node, paths []string) {
+	if len(paths) == 0 {
+		paths = []string{pass.Pkg.Path()}
+	}
+
+	covered := coveredTypes(pass.TypesInfo, node)
+
+	var missing []string
+
+	for _, path := range paths {
+		pkg := findPackage(pass.Pkg, path)
+		if pkg == nil {
+			pass.ReportRangef(node, "package %s is not imported", strconv.Quote(path))
+
+			continue
+		}
+
+		for _, t := range errorTypes(pkg, pkg == pass.Pkg) {
+			if !isCovered(t, covered) {
+				missing = append(missing, types.TypeString(t, types.RelativeTo(pass.Pkg)))
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		pass.ReportRangef(node, "missing error types in exhaustive classification: %s", strings.Join(missing, ", "))
+	}
+}
+
+// coverage is a type classified by a marked node.
+type coverage struct {
+	typ       types.Type
+	alternate bool // Whether the pointer-value alternate of typ is classified too
+}
+
+// coveredTypes returns the types classified by node.
+//
+// Type switch cases, composite literals and conversions classify exactly their type, while type
+// arguments of calls like SetTypeOf[T] classify T like Has does, including its pointer-value alternate.
+func coveredTypes(info *types.Info, node ast.Node) []coverage {
+	var covered []coverage
+
+	if s, ok := node.(*ast.TypeSwitchStmt); ok {
+		for _, clause := range s.Body.List {
+			for _, expr := range clause.(*ast.CaseClause).List {
+				if t := info.TypeOf(expr); t != nil {
+					covered = append(covered, coverage{typ: t})
+				}
+			}
+		}
+
+		return covered
+	}
+
+	addressed := make(map[*ast.CompositeLit]bool) // Operands of &, classifying the pointer type
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if inst, ok := info.Instances[n]; ok {
+				for i := range inst.TypeArgs.Len() {
+					covered = append(covered, coverage{typ: inst.TypeArgs.At(i), alternate: true})
+				}
+			}
+
+		case *ast.UnaryExpr:
+			// Pointers to composite literals like &MyError{}
+			if lit, ok := ast.Unparen(n.X).(*ast.CompositeLit); ok && n.Op == token.AND {
+				addressed[lit] = true
+
+				if t := info.TypeOf(n); isErrorType(t) {
+					covered = append(covered, coverage{typ: t})
+				}
+			}
+
+		case *ast.CompositeLit:
+			if t := info.TypeOf(n); !addressed[n] && isErrorType(t) {
+				covered = append(covered, coverage{typ: t})
+			}
+
+		case *ast.CallExpr:
+			// Conversions like (*MyError)(nil)
+			if tv, ok := info.Types[n.Fun]; ok && tv.IsType() && isErrorType(tv.Type) {
+				covered = append(covered, coverage{typ: tv.Type})
+			}
+		}
+
+		return true
+	})
+
+	return covered
+}
+
+// findPackage returns the package with the given path among pkg and its transitive imports.
+func findPackage(pkg *types.Package, path string) *types.Package {
+	seen := make(map[*types.Package]bool)
+	queue := []*types.Package{pkg}
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if p.Path() == path {
+			return p
+		}
+
+		for _, imp := range p.Imports() {
+			if !seen[imp] {
+				seen[imp] = true
+				queue = append(queue, imp)
+			}
+		}
+	}
+
+	return nil
+}
+
+// errorTypes returns the concrete error types declared in pkg, in the form implementing error.
+func errorTypes(pkg *types.Package, unexported bool) []types.Type {
+	var result []types.Type
+
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() || !tn.Exported() && !unexported {
+			continue
+		}
+
+		named, ok := tn.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+			continue
+		}
+
+		switch ptr := types.NewPointer(named); {
+		case analysisutil.ImplementsError(named):
+			result = append(result, named)
+		case analysisutil.ImplementsError(ptr):
+			result = append(result, ptr)
+		}
+	}
+
+	return result
+}
+
+// isCovered reports whether an error of type t is classified by one of covered.
+func isCovered(t types.Type, covered []coverage) bool {
+	return slices.ContainsFunc(covered, func(c coverage) bool {
+		if iface, ok := c.typ.Underlying().(*types.Interface); ok {
+			return types.Implements(t, iface)
+		}
+
+		return types.Identical(t, c.typ) || c.alternate && types.Identical(analysisutil.Alternate(c.typ), t)
+	})
+}
+
+func isErrorType(t types.Type) bool {
+	if t == nil || types.IsInterface(t) {
+		return false
+	}
+
+	return analysisutil.ImplementsError(t) || analysisutil.ImplementsError(types.NewPointer(t))
+}
@@ -0,0 +1,96 @@
+package a
+
+import "lib"
+
+const (
+	StatusNotFound            = 404
+	StatusConflict            = 409
+	StatusInternalServerError = 500
+	StatusServiceUnavailable  = 503
+)
+
+type localError struct{}
+
+func (*localError) Error() string { return "local" }
+
+func TypeOf[T error]() int { return 0 }
+
+func complete(err error) int {
+	//errors:exhaustive lib
+	switch err.(type) {
+	case *lib.NotFoundError:
+		return StatusNotFound
+	case lib.TimeoutError, lib.ConflictError:
+		return StatusConflict
+	}
+
+	return StatusInternalServerError
+}
+
+func pointerGap(err error) int {
+	//errors:exhaustive lib
+	switch err.(type) { // want `missing error types in exhaustive classification: lib.TimeoutError`
+	case *lib.NotFoundError, lib.ConflictError:
+		return StatusNotFound
+	case *lib.TimeoutError: // Does not match lib.TimeoutError values
+		return StatusServiceUnavailable
+	}
+
+	return StatusInternalServerError
+}
+
+func incomplete(err error) int {
+	//errors:exhaustive lib
+	switch err.(type) { // want `missing error types in exhaustive classification: lib.ConflictError, lib.TimeoutError`
+	case *lib.NotFoundError:
+		return StatusNotFound
+	}
+
+	return StatusInternalServerError
+}
+
+func byInterface(err error) int {
+	//errors:exhaustive lib
+	switch err.(type) {
+	case lib.Temporary:
+		return StatusServiceUnavailable
+	case *lib.NotFoundError, lib.ConflictError:
+		return StatusNotFound
+	}
+
+	return StatusInternalServerError
+}
+
+// statuses maps error types to HTTP status codes.
+//
+//errors:exhaustive lib
+var statuses = map[int]int{ // want `missing error types in exhaustive classification: lib.ConflictError`
+	TypeOf[*lib.NotFoundError](): StatusNotFound,
+	TypeOf[lib.TimeoutError]():   StatusServiceUnavailable,
+}
+
+//errors:exhaustive lib
+var registry = map[error]int{
+	&lib.NotFoundError{}: StatusNotFound,
+	lib.TimeoutError{}:   StatusServiceUnavailable,
+	lib.ConflictError{}:  StatusConflict,
+}
+
+//errors:exhaustive lib
+var pointerRegistry = map[error]int{ // want `missing error types in exhaustive classification: lib.TimeoutError`
+	&lib.NotFoundError{}: StatusNotFound,
+	&lib.TimeoutError{}:  StatusServiceUnavailable,
+	lib.ConflictError{}:  StatusConflict,
+}
+
+func local() []int {
+	//errors:exhaustive
+	return []int{TypeOf[*localError]()}
+}
+
+//errors:exhaustive example.com/unknown
+var unknown = map[error]int{} // want `package "example.com/unknown" is not imported`
+
+func misplaced() {
+	_ = 1 /* want `//errors:exhaustive directive must precede a statement or declaration` */ //errors:exhaustive lib
+}
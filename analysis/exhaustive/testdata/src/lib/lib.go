@@ -0,0 +1,25 @@
+package lib
+
+type NotFoundError struct{}
+
+func (*NotFoundError) Error() string { return "not found" }
+
+type TimeoutError struct{}
+
+func (TimeoutError) Error() string { return "timeout" }
+func (TimeoutError) Timeout() bool { return true }
+
+type ConflictError struct{}
+
+func (ConflictError) Error() string { return "conflict" }
+
+type Temporary interface {
+	error
+	Timeout() bool
+}
+
+type notExported struct{}
+
+func (notExported) Error() string { return "not exported" }
+
+type NotAnError struct{}
@@ -0,0 +1,178 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package analysisutil contains helpers shared by the analyzers of this module.
+package analysisutil
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ErrorsPath is the import path of this library.
+const ErrorsPath = "fillmore-labs.com/exp/errors"
+
+// ErrorType is the predeclared error interface.
+var ErrorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// Callee returns the generic origin of the function called by call, or nil if call is not a static
+// function call.
+func Callee(info *types.Info, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	return fn.Origin()
+}
+
+// IsFunc reports whether fn is the package-level function pkgPath.name.
+func IsFunc(fn *types.Func, pkgPath string, names ...string) bool {
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath {
+		return false
+	}
+
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return false
+	}
+
+	for _, name := range names {
+		if fn.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TypeArg returns the i-th type argument of a call to a generic function, or nil.
+func TypeArg(info *types.Info, call *ast.CallExpr, i int) types.Type {
+	id := FuncIdent(call)
+	if id == nil {
+		return nil
+	}
+
+	inst, ok := info.Instances[id]
+	if !ok || inst.TypeArgs.Len() <= i {
+		return nil
+	}
+
+	return inst.TypeArgs.At(i)
+}
+
+// FuncIdent returns the identifier naming the function called by call, or nil.
+func FuncIdent(call *ast.CallExpr) *ast.Ident {
+	fun := ast.Unparen(call.Fun)
+
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f
+	case *ast.SelectorExpr:
+		return f.Sel
+	default:
+		return nil
+	}
+}
+
+// LookupKind classifies calls that look up an error type in an error tree.
+type LookupKind int
+
+const (
+	// NoLookup is not a lookup.
+	NoLookup LookupKind = iota
+	// StdAs is a call to the standard library's errors.As.
+	StdAs
+	// Strict is a call to HasError or AsError.
+	Strict
+	// Flexible is a call to Has or As, which resolve pointer-value mismatches.
+	Flexible
+)
+
+// Lookup describes a call that looks up an error type in an error tree.
+type Lookup struct {
+	Kind   LookupKind
+	Func   *types.Func
+	Target types.Type // The type looked up
+}
+
+// ClassifyLookup returns the lookup performed by call, if any.
+func ClassifyLookup(info *types.Info, call *ast.CallExpr) Lookup {
+	fn := Callee(info, call)
+
+	switch {
+	case IsFunc(fn, "errors", "As"):
+		if len(call.Args) != 2 {
+			break
+		}
+
+		if t := info.TypeOf(call.Args[1]); t == nil {
+			break
+		} else if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			return Lookup{Kind: StdAs, Func: fn, Target: ptr.Elem()}
+		}
+
+	case IsFunc(fn, ErrorsPath, "HasError", "AsError"):
+		if target := TypeArg(info, call, 0); target != nil {
+			return Lookup{Kind: Strict, Func: fn, Target: target}
+		}
+
+	case IsFunc(fn, ErrorsPath, "Has", "As"):
+		if target := TypeArg(info, call, 0); target != nil {
+			return Lookup{Kind: Flexible, Func: fn, Target: target}
+		}
+	}
+
+	return Lookup{}
+}
+
+// Alternate returns the pointer-value alternate form of t: the element type for pointers to
+// named non-interface types, and the pointer type for named non-interface types. Otherwise, it returns nil.
+func Alternate(t types.Type) types.Type {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+		if !isNamedConcrete(t) {
+			return nil
+		}
+
+		return t
+	}
+
+	if !isNamedConcrete(t) {
+		return nil
+	}
+
+	return types.NewPointer(t)
+}
+
+// ImplementsError reports whether t implements error.
+func ImplementsError(t types.Type) bool {
+	return t != nil && types.Implements(t, ErrorType)
+}
+
+func isNamedConcrete(t types.Type) bool {
+	_, ok := types.Unalias(t).(*types.Named)
+
+	return ok && !types.IsInterface(t)
+}
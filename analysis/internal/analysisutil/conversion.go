@@ -0,0 +1,223 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysisutil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// ForEachConversion calls fn for each expression of non-interface type that is converted to an
+// interface type, either explicitly or implicitly by assignment, return, call, send or composite literal.
+func ForEachConversion(insp *inspector.Inspector, info *types.Info, fn func(expr ast.Expr, to types.Type)) {
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.ReturnStmt)(nil),
+		(*ast.SendStmt)(nil),
+		(*ast.ValueSpec)(nil),
+	}
+
+	check := func(expr ast.Expr, to types.Type) {
+		if expr == nil || to == nil || !types.IsInterface(to) {
+			return
+		}
+
+		if from := info.TypeOf(expr); from != nil && !types.IsInterface(from) && !isUntypedNil(from) {
+			fn(expr, to)
+		}
+	}
+
+	insp.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.ASSIGN || len(n.Lhs) != len(n.Rhs) {
+				break
+			}
+
+			for i, lhs := range n.Lhs {
+				check(n.Rhs[i], info.TypeOf(lhs))
+			}
+
+		case *ast.ValueSpec:
+			if n.Type == nil || len(n.Names) != len(n.Values) {
+				break
+			}
+
+			for _, value := range n.Values {
+				check(value, info.TypeOf(n.Type))
+			}
+
+		case *ast.CallExpr:
+			checkCall(info, n, check)
+
+		case *ast.CompositeLit:
+			checkCompositeLit(info, n, check)
+
+		case *ast.ReturnStmt:
+			sig := enclosingSignature(info, stack)
+			if sig == nil || sig.Results().Len() != len(n.Results) {
+				break
+			}
+
+			for i, result := range n.Results {
+				check(result, sig.Results().At(i).Type())
+			}
+
+		case *ast.SendStmt:
+			if ch, ok := info.TypeOf(n.Chan).Underlying().(*types.Chan); ok {
+				check(n.Value, ch.Elem())
+			}
+		}
+
+		return true
+	})
+}
+
+func checkCall(info *types.Info, call *ast.CallExpr, check func(ast.Expr, types.Type)) {
+	if tv, ok := info.Types[call.Fun]; ok && tv.IsType() {
+		// Explicit conversion
+		if len(call.Args) == 1 {
+			check(call.Args[0], tv.Type)
+		}
+
+		return
+	}
+
+	sig, ok := info.TypeOf(call.Fun).Underlying().(*types.Signature)
+	if !ok {
+		return // Builtin
+	}
+
+	params := sig.Params()
+	if params.Len() == 0 || len(call.Args) == 1 && params.Len() > 1 {
+		return // f(g()) with multiple results
+	}
+
+	for i, arg := range call.Args {
+		var to types.Type
+
+		switch {
+		case sig.Variadic() && i >= params.Len()-1:
+			if call.Ellipsis.IsValid() {
+				continue
+			}
+
+			slice, _ := params.At(params.Len() - 1).Type().Underlying().(*types.Slice)
+			to = slice.Elem()
+
+		case i < params.Len():
+			to = params.At(i).Type()
+		}
+
+		check(arg, to)
+	}
+}
+
+func checkCompositeLit(info *types.Info, lit *ast.CompositeLit, check func(ast.Expr, types.Type)) {
+	t := info.TypeOf(lit)
+	if t == nil {
+		return
+	}
+
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for i, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if id, ok := kv.Key.(*ast.Ident); ok {
+					if field, ok := info.Uses[id].(*types.Var); ok {
+						check(kv.Value, field.Type())
+					}
+				}
+
+				continue
+			}
+
+			if i < u.NumFields() {
+				check(elt, u.Field(i).Type())
+			}
+		}
+
+	case *types.Slice:
+		checkElements(lit, nil, u.Elem(), check)
+
+	case *types.Array:
+		checkElements(lit, nil, u.Elem(), check)
+
+	case *types.Map:
+		checkElements(lit, u.Key(), u.Elem(), check)
+	}
+}
+
+func checkElements(lit *ast.CompositeLit, key, elem types.Type, check func(ast.Expr, types.Type)) {
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key != nil {
+				check(kv.Key, key)
+			}
+
+			elt = kv.Value
+		}
+
+		if lit, ok := elt.(*ast.CompositeLit); ok && lit.Type == nil {
+			continue // Elided type, not an interface
+		}
+
+		check(elt, elem)
+	}
+}
+
+// enclosingSignature returns the signature of the innermost function in stack.
+func enclosingSignature(info *types.Info, stack []ast.Node) *types.Signature {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch f := stack[i].(type) {
+		case *ast.FuncLit:
+			sig, _ := info.TypeOf(f).(*types.Signature)
+
+			return sig
+
+		case *ast.FuncDecl:
+			if obj, ok := info.Defs[f.Name].(*types.Func); ok {
+				sig, _ := obj.Type().(*types.Signature)
+
+				return sig
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func isUntypedNil(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+
+	return ok && b.Kind() == types.UntypedNil
+}
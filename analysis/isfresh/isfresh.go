@@ -0,0 +1,166 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package isfresh defines an Analyzer that reports calls to errors.Is that can never match,
+// because the target is freshly allocated or not comparable.
+package isfresh
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `report errors.Is calls with fresh pointer or non-comparable targets
+
+errors.Is compares the errors in a tree with its target using == and calls
+their Is(error) bool methods. For targets like &MyError{}, new(MyError) or
+errors.New("..."), the comparison is with a freshly allocated value, which
+is never equal to an error in the tree:
+
+	if errors.Is(err, &MyError{}) { ... } // only Is methods can match
+
+Use Has[*MyError] to look for an error type, or compare with a sentinel
+variable. Likewise, a target of non-comparable type is never equal to an
+error in the tree.
+
+Targets whose type implements an Is(error) bool method are not reported,
+since errors of that type in the tree can match them by their own rules.`
+
+// Analyzer reports errors.Is calls with fresh pointer or non-comparable targets.
+var Analyzer = &analysis.Analyzer{
+	Name:     "isfresh",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, _ := n.(*ast.CallExpr)
+
+		if !analysisutil.IsFunc(analysisutil.Callee(pass.TypesInfo, call), "errors", "Is") || len(call.Args) != 2 {
+			return
+		}
+
+		target := ast.Unparen(call.Args[1])
+
+		if fn, ok := freshError(pass.TypesInfo, target); ok {
+			pass.ReportRangef(call, "errors.Is target %s(...) is freshly allocated and never equal to an error in the tree; compare with a sentinel variable", fn)
+
+			return
+		}
+
+		t := pass.TypesInfo.TypeOf(target)
+		if t == nil || types.IsInterface(t) || hasIsMethod(t) {
+			return
+		}
+
+		switch {
+		case isFresh(pass.TypesInfo, target):
+			pass.ReportRangef(call, "errors.Is target %s is freshly allocated and never equal to an error in the tree; use Has[%s] or compare with a sentinel value",
+				typeString(pass, t), typeString(pass, t))
+
+		case !types.Comparable(t):
+			pass.ReportRangef(call, "errors.Is target of non-comparable type %s is never equal to an error in the tree", typeString(pass, t))
+		}
+	})
+
+	return nil, nil
+}
+
+// isFresh reports whether x is &T{...} or new(T).
+func isFresh(info *types.Info, x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.UnaryExpr:
+		_, ok := ast.Unparen(x.X).(*ast.CompositeLit)
+
+		return x.Op == token.AND && ok
+
+	case *ast.CallExpr:
+		id, ok := ast.Unparen(x.Fun).(*ast.Ident)
+		if !ok || id.Name != "new" {
+			return false
+		}
+
+		_, ok = info.Uses[id].(*types.Builtin)
+
+		return ok
+	}
+
+	return false
+}
+
+// freshError reports whether x is a call of errors.New or fmt.Errorf, returning the function name.
+func freshError(info *types.Info, x ast.Expr) (string, bool) {
+	call, ok := x.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+
+	switch fn := analysisutil.Callee(info, call); {
+	case analysisutil.IsFunc(fn, "errors", "New"):
+		return "errors.New", true
+
+	case analysisutil.IsFunc(fn, "fmt", "Errorf"):
+		return "fmt.Errorf", true
+	}
+
+	return "", false
+}
+
+// hasIsMethod reports whether t or its pointer-value alternate have an Is(error) bool method.
+func hasIsMethod(t types.Type) bool {
+	if isMethod(t) {
+		return true
+	}
+
+	alt := analysisutil.Alternate(t)
+
+	return alt != nil && isMethod(alt)
+}
+
+func isMethod(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "Is")
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig, _ := fn.Type().(*types.Signature)
+
+	return sig.Params().Len() == 1 && sig.Results().Len() == 1 &&
+		types.Identical(sig.Params().At(0).Type(), types.Universe.Lookup("error").Type()) &&
+		types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(pass.Pkg))
+}
@@ -0,0 +1,38 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+)
+
+type MyError struct{ Code int }
+
+func (*MyError) Error() string { return "my error" }
+
+type MatchingError struct{}
+
+func (*MatchingError) Error() string { return "matching" }
+
+func (*MatchingError) Is(target error) bool {
+	_, ok := target.(*MatchingError)
+	return ok
+}
+
+type SliceError []string
+
+func (SliceError) Error() string { return "slice" }
+
+var ErrSentinel = &MyError{Code: 1}
+
+func checks(err error) {
+	_ = errors.Is(err, &MyError{})          // want `errors.Is target \*MyError is freshly allocated and never equal to an error in the tree; use Has\[\*MyError\] or compare with a sentinel value`
+	_ = errors.Is(err, new(MyError))        // want `errors.Is target \*MyError is freshly allocated`
+	_ = errors.Is(err, (&MyError{Code: 2})) // want `errors.Is target \*MyError is freshly allocated`
+	_ = errors.Is(err, SliceError{"a"})     // want `errors.Is target of non-comparable type SliceError is never equal to an error in the tree`
+
+	_ = errors.Is(err, errors.New("fresh"))       // want `errors.Is target errors.New\(...\) is freshly allocated and never equal to an error in the tree; compare with a sentinel variable`
+	_ = errors.Is(err, fmt.Errorf("fresh %d", 1)) // want `errors.Is target fmt.Errorf\(...\) is freshly allocated`
+
+	_ = errors.Is(err, ErrSentinel)
+	_ = errors.Is(err, &MatchingError{}) // Matched by (*MatchingError).Is
+}
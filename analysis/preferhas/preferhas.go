@@ -0,0 +1,101 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package preferhas defines an Analyzer that recommends Has and As over HasError and AsError
+// when the target type has an alternate form implementing error.
+package preferhas
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `recommend Has and As when both T and *T implement error
+
+HasError and AsError only match errors of exactly the requested type. When
+both the target type and its pointer-value alternate implement error, errors
+of the other form are missed. The preferhas analysis reports such calls and
+suggests switching to Has or As, which match both forms.
+
+Pointer targets *T are left to the asmismatch analysis, which reports them
+when T implements error.`
+
+// Analyzer recommends Has and As when both T and *T implement error.
+var Analyzer = &analysis.Analyzer{
+	Name:     "preferhas",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// replacements maps strict lookups to their flexible counterparts.
+var replacements = map[string]string{
+	"HasError": "Has",
+	"AsError":  "As",
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, _ := n.(*ast.CallExpr)
+
+		lookup := analysisutil.ClassifyLookup(pass.TypesInfo, call)
+		if lookup.Kind != analysisutil.Strict {
+			return
+		}
+
+		if _, ok := types.Unalias(lookup.Target).(*types.Pointer); ok {
+			return // Pointer targets *E with E implementing error are reported by asmismatch
+		}
+
+		alt := analysisutil.Alternate(lookup.Target)
+		if !analysisutil.ImplementsError(alt) {
+			return
+		}
+
+		id := analysisutil.FuncIdent(call)
+		name, replacement := lookup.Func.Name(), replacements[lookup.Func.Name()]
+
+		pass.Report(analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: name + " misses errors of type " + typeString(pass, alt) +
+				", which also implements error; use " + replacement + " to match both forms",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Replace " + name + " with " + replacement,
+				TextEdits: []analysis.TextEdit{{Pos: id.Pos(), End: id.End(), NewText: []byte(replacement)}},
+			}},
+		})
+	})
+
+	return nil, nil
+}
+
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(pass.Pkg))
+}
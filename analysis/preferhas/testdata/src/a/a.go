@@ -0,0 +1,30 @@
+package a
+
+import (
+	"fillmore-labs.com/exp/errors"
+)
+
+type ValueError struct{}
+
+func (ValueError) Error() string { return "value" }
+
+type PointerError struct{}
+
+func (*PointerError) Error() string { return "pointer" }
+
+type Interface interface {
+	error
+	Temporary() bool
+}
+
+func lookups(err error) {
+	_, _ = errors.HasError[ValueError](err)  // want `HasError misses errors of type \*ValueError, which also implements error; use Has to match both forms`
+	_, _ = errors.HasError[*ValueError](err) // Reported by asmismatch
+	_, _ = errors.HasError[*PointerError](err)
+	_, _ = errors.HasError[Interface](err)
+
+	var ve ValueError
+	_ = errors.AsError(err, &ve) // want `AsError misses errors of type \*ValueError, which also implements error; use As to match both forms`
+
+	_, _ = errors.Has[ValueError](err)
+}
@@ -0,0 +1,13 @@
+package errors
+
+func Has[T error](err error) (T, bool) { var zero T; return zero, false }
+
+func HasError[T error](err error) (T, bool) { var zero T; return zero, false }
+
+func As[T error](err error, target *T) bool { return false }
+
+func AsError[T error](err error, target *T) bool { return false }
+
+func HasType[T error](err error) bool { return false }
+
+func HasErrorType[T error](err error) bool { return false }
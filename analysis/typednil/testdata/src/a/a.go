@@ -0,0 +1,107 @@
+package a
+
+type MyError struct{}
+
+func (*MyError) Error() string { return "my error" }
+
+func Nil() error {
+	var e *MyError
+	return e // want `nil \*MyError returned as non-nil error`
+}
+
+func Possibly(failed bool) error {
+	var e *MyError
+	if failed {
+		e = &MyError{}
+	}
+
+	return e // want `possibly nil \*MyError returned as non-nil error`
+}
+
+func Guarded(failed bool) error {
+	var e *MyError
+	if failed {
+		e = &MyError{}
+	}
+
+	if e != nil {
+		return e
+	}
+
+	return nil
+}
+
+func GuardedEqual(failed bool) error {
+	var e *MyError
+	if failed {
+		e = &MyError{}
+	}
+
+	if e == nil {
+		return e // want `nil \*MyError returned as non-nil error`
+	}
+
+	return e
+}
+
+func Fresh() error { return &MyError{} }
+
+func Unknown(e *MyError) error { return e }
+
+func Typed() *MyError { return nil }
+
+type Wrapper struct {
+	err *MyError
+	ok  bool
+}
+
+func (w Wrapper) Error() string { return "wrapper" }
+
+func (w Wrapper) Unwrap() error {
+	var e *MyError
+	if w.ok {
+		e = w.err
+	}
+
+	return e // want `Unwrap returns possibly nil \*MyError as non-nil error, which breaks error tree traversal`
+}
+
+type Multi struct{}
+
+func (Multi) Error() string { return "multi" }
+
+func (Multi) Unwrap() []error {
+	var e *MyError
+	return []error{&MyError{}, e} // want `Unwrap returns nil \*MyError as non-nil error, which breaks error tree traversal`
+}
+
+type Cause struct{ err *MyError }
+
+func (c *Cause) Error() string { return "cause" }
+
+func (c *Cause) Unwrap() error {
+	return c.err // want `Unwrap returns possibly nil \*MyError as non-nil error, which breaks error tree traversal`
+}
+
+type GuardedCause struct{ err *MyError }
+
+func (c GuardedCause) Error() string { return "guarded cause" }
+
+func (c GuardedCause) Unwrap() error {
+	if c.err == nil {
+		return nil
+	}
+
+	return c.err
+}
+
+type Recorder struct{ last error }
+
+func (r *Recorder) Error() string { return "recorder" }
+
+func (r *Recorder) Unwrap() []error {
+	var e *MyError
+	r.last = e // Not an element of the result
+
+	return []error{&MyError{}}
+}
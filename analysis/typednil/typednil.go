@@ -0,0 +1,302 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package typednil defines an Analyzer that reports nil pointers converted to non-nil errors.
+package typednil
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+
+	"fillmore-labs.com/exp/errors/analysis/internal/analysisutil"
+)
+
+// Doc is the documentation of the analyzer.
+const Doc = `report typed nil pointers returned as error
+
+Converting a nil *MyError to error yields a non-nil error:
+
+	func f() error {
+		var e *MyError
+		if failed {
+			e = &MyError{}
+		}
+		return e // non-nil error, even when nothing failed
+	}
+
+The typednil analysis reports functions returning nil or possibly nil
+pointers through an error result, and Unwrap methods returning typed nils,
+which break the traversal of error trees. In Unwrap methods, pointer fields
+returned without a nil check count as possibly nil.`
+
+// Analyzer reports typed nil pointers returned as error.
+var Analyzer = &analysis.Analyzer{
+	Name:     "typednil",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ssainfo, _ := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+
+	for _, fn := range ssainfo.SrcFuncs {
+		unwrap := isUnwrap(fn)
+
+		var results map[ssa.Value]bool
+		if unwrap {
+			results = resultSlices(fn)
+		}
+
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				switch instr := instr.(type) {
+				case *ssa.Return:
+					for _, result := range instr.Results {
+						checkConversion(pass, result, instr.Pos(), unwrap)
+					}
+
+				case *ssa.Store:
+					// Elements of the []error returned by Unwrap
+					if addr, ok := instr.Addr.(*ssa.IndexAddr); ok && results[addr.X] {
+						checkConversion(pass, instr.Val, instr.Pos(), unwrap)
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// isUnwrap reports whether fn is an Unwrap() error or Unwrap() []error method.
+func isUnwrap(fn *ssa.Function) bool {
+	sig := fn.Signature
+	if fn.Name() != "Unwrap" || sig.Recv() == nil || sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+
+	t := sig.Results().At(0).Type()
+	if slice, ok := t.(*types.Slice); ok {
+		t = slice.Elem()
+	}
+
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// resultSlices returns the slices and their backing arrays that fn returns.
+func resultSlices(fn *ssa.Function) map[ssa.Value]bool {
+	results := make(map[ssa.Value]bool)
+
+	var add func(v ssa.Value)
+	add = func(v ssa.Value) {
+		if results[v] {
+			return
+		}
+
+		results[v] = true
+
+		switch v := v.(type) {
+		case *ssa.Slice:
+			add(v.X)
+
+		case *ssa.Phi:
+			for _, edge := range v.Edges {
+				add(edge)
+			}
+		}
+	}
+
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if ret, ok := instr.(*ssa.Return); ok {
+				for _, result := range ret.Results {
+					add(result)
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// checkConversion reports v when it converts a possibly nil pointer to error.
+func checkConversion(pass *analysis.Pass, v ssa.Value, pos token.Pos, unwrap bool) {
+	mi, ok := v.(*ssa.MakeInterface)
+	if !ok || !analysisutil.ImplementsError(mi.Type()) {
+		return
+	}
+
+	if _, ok := mi.X.Type().Underlying().(*types.Pointer); !ok {
+		return
+	}
+
+	if !pos.IsValid() {
+		pos = mi.Parent().Pos()
+	}
+
+	// Unwrap methods usually return a field holding the wrapped error, which may be nil.
+	state := nilness(mi.X, mi.Block(), unwrap, make(map[ssa.Value]bool))
+
+	var what string
+
+	switch state {
+	case isNil:
+		what = "nil"
+	case mayBeNil:
+		what = "possibly nil"
+	default:
+		return
+	}
+
+	t := types.TypeString(mi.X.Type(), types.RelativeTo(pass.Pkg))
+
+	if unwrap {
+		pass.Reportf(pos, "Unwrap returns %s %s as non-nil error, which breaks error tree traversal", what, t)
+
+		return
+	}
+
+	pass.Reportf(pos, "%s %s returned as non-nil error", what, t)
+}
+
+// nilState is what is known about a pointer being nil.
+type nilState int
+
+const (
+	unknown nilState = iota
+	isNil
+	notNil
+	mayBeNil
+)
+
+// nilness returns whether v is nil at the start of block b. With fields set, unchecked reads of
+// struct fields may be nil.
+func nilness(v ssa.Value, b *ssa.BasicBlock, fields bool, visited map[ssa.Value]bool) nilState {
+	if s := guarded(v, b); s != unknown {
+		return s
+	}
+
+	switch v := v.(type) {
+	case *ssa.Const:
+		if v.IsNil() {
+			return isNil
+		}
+
+	case *ssa.Alloc, *ssa.FieldAddr, *ssa.IndexAddr, *ssa.MakeClosure:
+		return notNil
+
+	case *ssa.Field:
+		if fields {
+			return mayBeNil
+		}
+
+	case *ssa.UnOp:
+		if _, ok := v.X.(*ssa.FieldAddr); ok && fields && v.Op == token.MUL {
+			return mayBeNil
+		}
+
+	case *ssa.Phi:
+		if visited[v] {
+			return unknown
+		}
+
+		visited[v] = true
+
+		state := unknown
+		for i, edge := range v.Edges {
+			switch nilness(edge, v.Block().Preds[i], fields, visited) {
+			case isNil, mayBeNil:
+				state = mayBeNil
+			}
+		}
+
+		return state
+	}
+
+	return unknown
+}
+
+// guarded returns what dominating nil checks imply about v in block b.
+func guarded(v ssa.Value, b *ssa.BasicBlock) nilState {
+	for ; b != nil; b = b.Idom() {
+		if len(b.Preds) != 1 {
+			continue
+		}
+
+		pred := b.Preds[0]
+
+		ifInstr, ok := pred.Instrs[len(pred.Instrs)-1].(*ssa.If)
+		if !ok {
+			continue
+		}
+
+		cond, ok := ifInstr.Cond.(*ssa.BinOp)
+		if !ok || (cond.Op != token.EQL && cond.Op != token.NEQ) || !comparesWithNil(cond, v) {
+			continue
+		}
+
+		// The true branch of x == nil and the false branch of x != nil see nil.
+		if (b == pred.Succs[0]) == (cond.Op == token.EQL) {
+			return isNil
+		}
+
+		return notNil
+	}
+
+	return unknown
+}
+
+func comparesWithNil(cond *ssa.BinOp, v ssa.Value) bool {
+	isNilConst := func(x ssa.Value) bool {
+		c, ok := x.(*ssa.Const)
+
+		return ok && c.IsNil()
+	}
+
+	return sameValue(cond.X, v) && isNilConst(cond.Y) || sameValue(cond.Y, v) && isNilConst(cond.X)
+}
+
+// sameValue reports whether x and y are the same value, treating repeated reads of a field as equal.
+func sameValue(x, y ssa.Value) bool {
+	if x == y {
+		return true
+	}
+
+	switch x := x.(type) {
+	case *ssa.Field:
+		y, ok := y.(*ssa.Field)
+
+		return ok && x.X == y.X && x.Field == y.Field
+
+	case *ssa.UnOp:
+		y, ok := y.(*ssa.UnOp)
+		if !ok || x.Op != token.MUL || y.Op != token.MUL {
+			return false
+		}
+
+		fx, okx := x.X.(*ssa.FieldAddr)
+		fy, oky := y.X.(*ssa.FieldAddr)
+
+		return okx && oky && fx.X == fy.X && fx.Field == fy.Field
+	}
+
+	return false
+}
@@ -28,7 +28,8 @@ package errors
 // the found error is `MyError`, or vice versa), or if the error has a method
 // `As(any) bool` that returns `true`. To accommodate pointer-value mismatches
 // in `As` implementations, `As` tries different variations of the target type.
-// In the latter case, the `As` method is responsible for setting `target`.
+// In the latter case, the `As` method is responsible for setting `target`. When it returns
+// true, but leaves a nil pointer or interface, `target` is restored and the search continues.
 //
 // An error type might provide an `As` method, so it can be treated as if it were a
 // different error type.
@@ -50,10 +51,12 @@ func As[T error](err error, target *T) bool {
 
 		if handler == nil {
 			// Lazily initialize the handler only when a direct type assertion fails.
-			handler = newAltHandler(target)
+			handler = altHandlerFor[T]()
 		}
 
 		if result, ok := handler.handleAssert(err); ok {
+			observeMismatch[T](err, StrategyAlternate)
+
 			*target = result
 
 			return true
@@ -62,13 +65,15 @@ func As[T error](err error, target *T) bool {
 		if x, ok := err.(interface{ As(any) bool }); ok {
 			// First, try the standard errors.As contract. This works when T matches
 			// the type expected by the As method.
-			if x.As(target) {
+			if callAsTarget(x, target) {
 				return true
 			}
 
 			// If the standard call fails, it might be due to a pointer-vs-value mismatch
 			// between T and the type the As method is designed to handle.
 			if result, ok := handler.handleAs(x); ok {
+				observeMismatch[T](err, StrategyAsAlternate)
+
 				*target = result
 
 				return true
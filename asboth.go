@@ -0,0 +1,65 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+// AsBoth finds the first error in `err`'s tree that has type `P` or `E`, where `P` is `*E`,
+// and if one is found, sets target to that error as `P` and returns true. Otherwise, it returns false.
+//
+// AsBoth behaves like [As] for the pointer type `P`, using plain type assertions instead of
+// reflection, see [HasBoth].
+//
+// AsBoth panics if `target` is a nil pointer.
+func AsBoth[E any, P interface {
+	*E
+	error
+}](err error, target *P,
+) bool {
+	if target == nil {
+		panic("errors: target cannot be nil")
+	}
+
+	result, ok := HasBoth[E, P](err)
+	if ok {
+		*target = result
+	}
+
+	return ok
+}
+
+// AsBothValue finds the first error in `err`'s tree that has type `E` or `P`, where `P` is `*E`,
+// and if one is found, sets target to that error as `E` and returns true. Otherwise, it returns false.
+//
+// AsBothValue behaves like [As] for the value type `E`, using plain type assertions instead of
+// reflection, see [HasBothValue].
+//
+// AsBothValue panics if `target` is a nil pointer.
+func AsBothValue[E any, P interface {
+	*E
+	error
+}](err error, target *E,
+) bool {
+	if target == nil {
+		panic("errors: target cannot be nil")
+	}
+
+	result, ok := HasBothValue[E, P](err)
+	if ok {
+		*target = result
+	}
+
+	return ok
+}
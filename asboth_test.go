@@ -0,0 +1,63 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestAsBoth(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", MyValueError(8))
+
+	var p *MyValueError
+	if !AsBoth(err, &p) {
+		t.Errorf("Expected to find *MyValueError, but didn't.")
+	} else if *p != MyValueError(8) {
+		t.Errorf("Expected *MyValueError(8), but got %d", int(*p))
+	}
+
+	pointerErr := MyValueError(9)
+
+	var v MyValueError
+	if !AsBothValue(fmt.Errorf("wrapped: %w", &pointerErr), &v) {
+		t.Errorf("Expected to find MyValueError, but didn't.")
+	} else if v != MyValueError(9) {
+		t.Errorf("Expected MyValueError(9), but got %d", int(v))
+	}
+
+	var o *MyPointerOnlyError
+	if AsBoth(err, &o) {
+		t.Errorf("Expected to not find *MyPointerOnlyError, but did.")
+	}
+}
+
+func TestAsBothPanic(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected AsBoth to panic on nil target, but it didn't.")
+		}
+	}()
+
+	_ = AsBoth[MyValueError](nil, nil)
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import "reflect"
+
+// callAs calls an `As(any) bool` method with ptr, which is reset to the zero value of T first,
+// since it may be reused for several errors. Results of As methods that return true without
+// producing a valid result are rejected, see [validAsResult].
+func callAs[T error](x interface{ As(any) bool }, ptr *T) (T, bool) {
+	var zero T
+
+	*ptr = zero
+	if !x.As(ptr) || !validAsResult(*ptr) {
+		return zero, false
+	}
+
+	return *ptr, true
+}
+
+// callAsTarget calls an `As(any) bool` method with a caller-provided target, restoring its
+// previous value when As does not produce a valid result.
+func callAsTarget[T error](x interface{ As(any) bool }, target *T) bool {
+	saved := *target
+	if x.As(target) && validAsResult(*target) {
+		return true
+	}
+
+	*target = saved
+
+	return false
+}
+
+// validAsResult reports whether the result of an As method that returned true is usable.
+//
+// Nil pointers and interfaces are rejected; they are what As methods leave behind when they return
+// true without setting the target. For other types an untouched target cannot be distinguished from
+// a zero value, so those results are accepted.
+func validAsResult[T error](result T) bool {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Interface:
+		return any(result) != nil
+
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return !reflect.ValueOf(result).IsNil()
+
+	default:
+		return true
+	}
+}
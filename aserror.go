@@ -29,7 +29,8 @@ package errors
 //   - The error's concrete value is assignable to `T`.
 //   - The error has a method `As(any) bool`, and calling `As` with `target`
 //     returns `true`. In this case, the `As` method is responsible for setting
-//     the value of `target`.
+//     the value of `target`. When it leaves a nil pointer or interface, `target`
+//     is restored and the search continues.
 //
 // AsError panics if `target` is a nil pointer.
 func AsError[T error](err error, target *T) bool {
@@ -46,7 +47,7 @@ func AsError[T error](err error, target *T) bool {
 
 		if x, ok := err.(interface{ As(any) bool }); ok {
 			// Try the standard errors.As contract.
-			if x.As(target) {
+			if callAsTarget(x, target) {
 				return true
 			}
 		}
@@ -0,0 +1,140 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+// lookup is a single lookup of *MyValueError or MyPointerError, depending on the scenario.
+type lookup struct {
+	name string
+	find func(err error) bool
+}
+
+// scenario is an error tree together with the lookups to benchmark on it.
+type scenario struct {
+	name    string
+	err     error
+	found   bool // whether errors.As finds the target
+	lookups []lookup
+	allocs  map[string]float64 // maximum allocations per lookup, including escaping As targets
+}
+
+func wrapDeep(err error, depth int) error {
+	for i := range depth {
+		err = fmt.Errorf("level %d: %w", i, err)
+	}
+
+	return err
+}
+
+func valueLookups() []lookup {
+	return []lookup{
+		{"Has", func(err error) bool { _, ok := Has[MyValueError](err); return ok }},
+		{"HasError", func(err error) bool { _, ok := HasError[MyValueError](err); return ok }},
+		{"As", func(err error) bool { var t MyValueError; return As(err, &t) }},
+		{"AsError", func(err error) bool { var t MyValueError; return AsError(err, &t) }},
+		{"errors.As", func(err error) bool { var t MyValueError; return errors.As(err, &t) }},
+	}
+}
+
+func pointerLookups() []lookup {
+	return []lookup{
+		{"Has", func(err error) bool { _, ok := Has[*MyPointerError](err); return ok }},
+		{"HasError", func(err error) bool { _, ok := HasError[*MyPointerError](err); return ok }},
+		{"As", func(err error) bool { var t *MyPointerError; return As(err, &t) }},
+		{"AsError", func(err error) bool { var t *MyPointerError; return AsError(err, &t) }},
+		{"errors.As", func(err error) bool { var t *MyPointerError; return errors.As(err, &t) }},
+	}
+}
+
+func scenarios() []scenario {
+	valueErr := MyValueError(8)
+
+	return []scenario{
+		{
+			name: "RootHit", err: MyValueError(8), found: true, lookups: valueLookups(),
+			allocs: map[string]float64{"Has": 0, "HasError": 0, "As": 1, "AsError": 1},
+		},
+		{
+			name: "DeepHit", err: wrapDeep(MyValueError(8), 32), found: true, lookups: valueLookups(),
+			allocs: map[string]float64{"Has": 0, "HasError": 0, "As": 1, "AsError": 1},
+		},
+		{
+			name: "Miss", err: wrapDeep(errors.New("miss"), 32), found: false, lookups: valueLookups(),
+			allocs: map[string]float64{"Has": 0, "HasError": 0, "As": 1, "AsError": 1},
+		},
+		{
+			// MyAsError.As populates **MyPointerError targets.
+			name: "AsMethodHit", err: wrapDeep(MyAsError(8), 4), found: true, lookups: pointerLookups(),
+			allocs: map[string]float64{"Has": 2, "HasError": 2, "As": 2, "AsError": 2},
+		},
+		{
+			// errors.As misses pointers when looking for values.
+			name: "PointerValueMismatch", err: wrapDeep(&valueErr, 4), found: false, lookups: valueLookups(),
+			allocs: map[string]float64{"Has": 0, "HasError": 0, "As": 1, "AsError": 1},
+		},
+	}
+}
+
+func BenchmarkLookup(b *testing.B) {
+	for _, s := range scenarios() {
+		b.Run(s.name, func(b *testing.B) {
+			for _, l := range s.lookups {
+				b.Run(l.name, func(b *testing.B) {
+					b.ReportAllocs()
+
+					for range b.N {
+						l.find(s.err)
+					}
+				})
+			}
+		})
+	}
+}
+
+// TestLookupAllocs fails when a change increases the allocations of lookups on the hot paths.
+//
+//nolint:paralleltest // testing.AllocsPerRun must not be called from parallel tests
+func TestLookupAllocs(t *testing.T) {
+	for _, s := range scenarios() {
+		for _, l := range s.lookups {
+			want := s.found
+			if l.name == "Has" || l.name == "As" {
+				want = s.found || s.name == "PointerValueMismatch"
+			}
+
+			if got := l.find(s.err); got != want {
+				t.Errorf("%s/%s: Expected found == %t, but got %t", s.name, l.name, want, got)
+			}
+
+			limit, ok := s.allocs[l.name]
+			if !ok {
+				continue
+			}
+
+			if allocs := testing.AllocsPerRun(100, func() { l.find(s.err) }); allocs > limit {
+				t.Errorf("%s/%s: Expected at most %.0f allocations, but got %.1f", s.name, l.name, limit, allocs)
+			}
+		}
+	}
+}
@@ -16,7 +16,10 @@
 
 package errors
 
-import "iter"
+import (
+	"iter"
+	"sync"
+)
 
 // DepthFirstErrorTree traverses an error tree depth-first and returns a sequence of errors starting from the root error.
 // It supports both single error unwrapping (`Unwrap() error`) and multi-error unwrapping (`Unwrap() []error`) mechanisms.
@@ -26,6 +29,10 @@ func DepthFirstErrorTree(root error) iter.Seq[error] {
 		base := [4]error{root} // Allocated on the stack
 		stack := base[:1]
 
+		// spill is a pooled stack, used when the tree is too wide for base.
+		// It never aliases base, so base does not escape to the heap.
+		var spill *[]error
+
 		for top := 0; top >= 0; top = len(stack) - 1 {
 			err := stack[top]
 			stack = stack[:top]
@@ -35,20 +42,114 @@ func DepthFirstErrorTree(root error) iter.Seq[error] {
 			}
 
 			if !yield(err) {
-				return
+				break
 			}
 
 			switch x := err.(type) {
 			case interface{ Unwrap() []error }:
 				unwrap := x.Unwrap()
+				if len(stack)+len(unwrap) > cap(stack) {
+					spill = growStack(spill, stack, len(unwrap))
+					stack = *spill
+				}
+
 				// Push children in reverse order to visit them in their original order (depth-first).
 				for i := len(unwrap) - 1; i >= 0; i-- {
 					stack = append(stack, unwrap[i])
 				}
 
 			case interface{ Unwrap() error }:
+				// We just popped an error, so there is room for one more.
 				stack = append(stack, x.Unwrap())
 			}
 		}
+
+		if spill != nil {
+			releaseStack(spill)
+		}
+	}
+}
+
+// maxPooledStack limits the capacity of stacks returned to the pool.
+const maxPooledStack = 1 << 10
+
+var stackPool = sync.Pool{
+	New: func() any {
+		stack := make([]error, 0, 32)
+
+		return &stack
+	},
+}
+
+// growStack returns a pooled stack holding the errors of stack with room for n more.
+// spill is the previously used pooled stack, or nil.
+func growStack(spill *[]error, stack []error, n int) *[]error {
+	if spill == nil {
+		spill, _ = stackPool.Get().(*[]error)
+	}
+
+	if need := len(stack) + n; cap(*spill) < need {
+		grown := make([]error, 0, max(2*cap(*spill), need))
+		*spill = grown
+	}
+
+	*spill = append((*spill)[:0], stack...)
+
+	return spill
+}
+
+// releaseStack returns a spilled stack after a traversal. Tests replace it to observe releases.
+var releaseStack = putStack
+
+// putStack clears the references held by a stack and returns it to the pool.
+func putStack(spill *[]error) {
+	if cap(*spill) > maxPooledStack {
+		return
+	}
+
+	clear((*spill)[:cap(*spill)])
+	*spill = (*spill)[:0]
+	stackPool.Put(spill)
+}
+
+// depthFirstWithPath traverses an error tree in the same order as [DepthFirstErrorTree], additionally
+// yielding the ancestors of each error, starting with the root. The path is only valid during the yield call.
+func depthFirstWithPath(root error) iter.Seq2[error, []error] {
+	return func(yield func(error, []error) bool) {
+		type entry struct {
+			err   error
+			depth int
+		}
+
+		var path []error
+
+		stack := []entry{{err: root}}
+
+		for top := 0; top >= 0; top = len(stack) - 1 {
+			e := stack[top]
+			stack = stack[:top]
+
+			if e.err == nil {
+				continue
+			}
+
+			path = path[:e.depth]
+			if !yield(e.err, path) {
+				return
+			}
+
+			path = append(path, e.err)
+
+			switch x := e.err.(type) {
+			case interface{ Unwrap() []error }:
+				unwrap := x.Unwrap()
+				for i := len(unwrap) - 1; i >= 0; i-- {
+					stack = append(stack, entry{err: unwrap[i], depth: e.depth + 1})
+				}
+
+			case interface{ Unwrap() error }:
+				stack = append(stack, entry{err: x.Unwrap(), depth: e.depth + 1})
+			}
+		}
 	}
 }
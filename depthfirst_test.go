@@ -19,6 +19,7 @@ package errors
 import (
 	"errors"
 	"slices"
+	"strconv"
 	"testing"
 )
 
@@ -89,3 +90,200 @@ func TestDepthFirstErrorTree(t *testing.T) {
 		}
 	})
 }
+
+// leafErrors returns n distinct leaf errors.
+func leafErrors(prefix string, n int) []error {
+	leaves := make([]error, n)
+	for i := range leaves {
+		leaves[i] = errors.New(prefix + strconv.Itoa(i))
+	}
+
+	return leaves
+}
+
+func TestDepthFirstErrorTreeSpill(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WideJoin", func(t *testing.T) {
+		t.Parallel()
+
+		// More children than the inline stack holds.
+		leaves := leafErrors("leaf ", 10)
+		root := &multiWrapError{msg: "root", errs: leaves}
+		expected := append([]error{root}, leaves...)
+
+		if got := slices.Collect(DepthFirstErrorTree(root)); !slices.Equal(got, expected) {
+			t.Errorf("DepthFirstErrorTree() traversal order incorrect\n got: %v\nwant: %v", got, expected)
+		}
+	})
+
+	t.Run("NestedWideJoins", func(t *testing.T) {
+		t.Parallel()
+
+		leavesA, leavesB := leafErrors("a", 6), leafErrors("b", 70)
+		wideA := &multiWrapError{msg: "wide a", errs: leavesA}
+		wideB := &multiWrapError{msg: "wide b", errs: leavesB}
+		single := &singleWrapError{msg: "single", err: wideB}
+		root := &multiWrapError{msg: "root", errs: []error{wideA, err1, single, err2}}
+
+		expected := []error{root, wideA}
+		expected = append(expected, leavesA...)
+		expected = append(expected, err1, single, wideB)
+		expected = append(expected, leavesB...)
+		expected = append(expected, err2)
+
+		if got := slices.Collect(DepthFirstErrorTree(root)); !slices.Equal(got, expected) {
+			t.Errorf("DepthFirstErrorTree() traversal order incorrect\n got: %v\nwant: %v", got, expected)
+		}
+	})
+}
+
+//nolint:paralleltest // Replaces the global releaseStack
+func TestDepthFirstErrorTreeBreakReturnsStack(t *testing.T) {
+	var released []*[]error
+
+	defer func(release func(*[]error)) { releaseStack = release }(releaseStack)
+
+	releaseStack = func(spill *[]error) {
+		released = append(released, spill)
+		putStack(spill)
+	}
+
+	wide := &multiWrapError{msg: "wide", errs: leafErrors("stale ", 20)}
+
+	for err := range DepthFirstErrorTree(wide) {
+		if err != wide {
+			break // Leave entries on the spilled stack
+		}
+	}
+
+	if len(released) != 1 {
+		t.Fatalf("Expected the spilled stack to be released once after break, but got %d releases", len(released))
+	}
+
+	spill := released[0]
+	if len(*spill) != 0 {
+		t.Errorf("Expected an empty released stack, but got length %d", len(*spill))
+	}
+
+	for i, err := range (*spill)[:cap(*spill)] {
+		if err != nil {
+			t.Errorf("Expected a cleared released stack, but entry %d holds %v", i, err)
+
+			break
+		}
+	}
+
+	// The next traversal may reuse the released stack and must not see entries from the previous one.
+	leaves := leafErrors("fresh ", 8)
+	root := &multiWrapError{msg: "root", errs: leaves}
+	expected := append([]error{root}, leaves...)
+
+	if got := slices.Collect(DepthFirstErrorTree(root)); !slices.Equal(got, expected) {
+		t.Errorf("DepthFirstErrorTree() traversal after break incorrect\n got: %v\nwant: %v", got, expected)
+	}
+
+	if len(released) != 2 {
+		t.Errorf("Expected the spilled stack to be released after a complete traversal, but got %d releases", len(released))
+	}
+}
+
+func TestDepthFirstWithPath(t *testing.T) {
+	t.Parallel()
+
+	child1 := &singleWrapError{msg: "child 1", err: errGrand1}
+	root := &multiWrapError{msg: "root", errs: []error{child1, nil, errChild2}}
+	expected := map[error][]error{
+		root:      nil,
+		child1:    {root},
+		errGrand1: {root, child1},
+		errChild2: {root},
+	}
+
+	var count int
+
+	for err, path := range depthFirstWithPath(root) {
+		count++
+
+		if want := expected[err]; !slices.Equal(path, want) {
+			t.Errorf("Expected path %v for %v, but got %v", want, err, path)
+		}
+	}
+
+	if count != len(expected) {
+		t.Errorf("Expected %d errors, but got %d", len(expected), count)
+	}
+}
+
+// benchmarkTrees returns error trees of different shapes, each with target as the last error in depth-first order.
+func benchmarkTrees(target error) []struct {
+	name string
+	err  error
+} {
+	chain := func(depth int, err error) error {
+		for i := range depth {
+			err = &singleWrapError{msg: "level " + strconv.Itoa(i), err: err}
+		}
+
+		return err
+	}
+
+	join := func(width int, last error) error {
+		errs := make([]error, width)
+		for i := range width - 1 {
+			errs[i] = errors.New("sibling " + strconv.Itoa(i))
+		}
+
+		errs[width-1] = last
+
+		return &multiWrapError{msg: "join", errs: errs}
+	}
+
+	nested := target
+	for range 4 {
+		nested = join(4, chain(2, nested))
+	}
+
+	return []struct {
+		name string
+		err  error
+	}{
+		{"SingleChain", chain(3, target)},
+		{"DeepChain", chain(64, target)},
+		{"WideJoin", join(64, target)},
+		{"NestedJoins", join(8, nested)},
+	}
+}
+
+func BenchmarkDepthFirstErrorTree(b *testing.B) {
+	for _, tree := range benchmarkTrees(err1) {
+		b.Run(tree.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				var last error
+				for err := range DepthFirstErrorTree(tree.err) {
+					last = err
+				}
+
+				if last != err1 {
+					b.Fatal("Expected to end with err1")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHasShapes(b *testing.B) {
+	for _, tree := range benchmarkTrees(&pointerReceiverError{code: 1}) {
+		b.Run(tree.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				if _, ok := Has[*pointerReceiverError](tree.err); !ok {
+					b.Fatal("Expected to find *pointerReceiverError")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package errorstest provides test helpers for fillmore-labs.com/exp/errors.
+package errorstest
+
+import (
+	"sync"
+	"testing"
+
+	"fillmore-labs.com/exp/errors"
+)
+
+// FailOnMismatch fails the test if any lookup resolves a pointer-value mismatch while the test runs,
+// reporting the requested and found types and the location of the lookup.
+//
+// The mismatch hook is global, so mismatches of concurrently running tests are reported, too.
+// Use FailOnMismatch in tests that do not call [testing.T.Parallel].
+func FailOnMismatch(tb testing.TB) {
+	tb.Helper()
+
+	var (
+		mu         sync.Mutex
+		mismatches []errors.Mismatch
+	)
+
+	previous := errors.SetMismatchHook(func(m errors.Mismatch) {
+		mu.Lock()
+		mismatches = append(mismatches, m)
+		mu.Unlock()
+	})
+
+	tb.Cleanup(func() {
+		errors.SetMismatchHook(previous)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, m := range mismatches {
+			tb.Errorf("pointer-value mismatch: %s", m)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errorstest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"fillmore-labs.com/exp/errors"
+	"fillmore-labs.com/exp/errors/errorstest"
+)
+
+type valueError struct{}
+
+func (valueError) Error() string { return "value error" }
+
+// recorder captures the failures of a test.
+type recorder struct {
+	testing.TB
+	failures []string
+	cleanups []func()
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *recorder) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+//nolint:paralleltest // The mismatch hook is global
+func TestFailOnMismatch(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", valueError{})
+
+	r := &recorder{TB: t}
+	errorstest.FailOnMismatch(r)
+
+	if _, ok := errors.Has[valueError](err); !ok {
+		t.Fatal("Expected to find valueError")
+	}
+
+	if _, ok := errors.Has[*valueError](err); !ok {
+		t.Fatal("Expected to find *valueError")
+	}
+
+	r.finish()
+
+	if len(r.failures) != 1 {
+		t.Fatalf("Expected 1 failure, but got %v", r.failures)
+	}
+
+	if _, ok := errors.Has[*valueError](err); !ok {
+		t.Fatal("Expected to find *valueError")
+	}
+
+	if len(r.failures) != 1 {
+		t.Errorf("Expected the hook to be removed after the test, but got %v", r.failures)
+	}
+}
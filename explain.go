@@ -0,0 +1,180 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Strategy is a way [Has] tries to match an error in the tree.
+type Strategy uint8
+
+const (
+	// StrategyAssert is a type assertion to the target type.
+	StrategyAssert Strategy = iota + 1
+	// StrategyAlternate is a type assertion to the pointer-value alternate form of the target type.
+	StrategyAlternate
+	// StrategyAs is a call to the error's `As(any) bool` method with a pointer to the target type.
+	StrategyAs
+	// StrategyAsAlternate is a call to the error's `As(any) bool` method with a pointer to the
+	// alternate form of the target type.
+	StrategyAsAlternate
+)
+
+// String returns a short description of the strategy.
+func (s Strategy) String() string {
+	switch s {
+	case StrategyAssert:
+		return "assert"
+	case StrategyAlternate:
+		return "alternate"
+	case StrategyAs:
+		return "As"
+	case StrategyAsAlternate:
+		return "As-alternate"
+	default:
+		return "unknown"
+	}
+}
+
+// Attempt is the outcome of a [Strategy] applied to an error in the tree.
+type Attempt struct {
+	Strategy Strategy
+	Matched  bool
+}
+
+// NodeReport lists the strategies tried on an error in the tree.
+type NodeReport struct {
+	Node     error     // The error in the tree
+	Depth    int       // The number of ancestors of the error
+	Attempts []Attempt // The strategies tried, in order
+}
+
+// Report describes how [Has] searched an error tree, as returned by [Explain].
+type Report struct {
+	Target    reflect.Type // The queried type
+	Alternate reflect.Type // The pointer-value alternate form of Target, nil if it does not implement error
+	Nodes     []NodeReport // The visited errors, in depth-first order
+	Found     bool         // Whether the lookup succeeded
+	Result    error        // The found error, nil if not found
+}
+
+// Explain performs the same lookup as [Has] and records, for each visited error,
+// which strategies were tried and whether they matched.
+//
+// Explain is intended for debugging and tests; it is slower than [Has].
+func Explain[T error](err error) Report {
+	handler := altHandlerFor[T]()
+
+	r := Report{Target: reflect.TypeFor[T](), Alternate: handler.alternate()}
+
+	for node, path := range depthFirstWithPath(err) {
+		n := NodeReport{Node: node, Depth: len(path)}
+		result, ok := explainNode(node, handler, &n)
+		r.Nodes = append(r.Nodes, n)
+
+		if ok {
+			r.Found, r.Result = true, result
+
+			break
+		}
+	}
+
+	return r
+}
+
+// explainNode applies the strategies of [Has] to a single error, recording them in n.
+func explainNode[T error](err error, handler altHandler[T], n *NodeReport) (T, bool) {
+	record := func(s Strategy, ok bool) bool {
+		n.Attempts = append(n.Attempts, Attempt{Strategy: s, Matched: ok})
+
+		return ok
+	}
+
+	if target, ok := err.(T); record(StrategyAssert, ok) {
+		return target, true
+	}
+
+	if handler.alternate() != nil {
+		if result, ok := handler.handleAssert(err); record(StrategyAlternate, ok) {
+			return result, true
+		}
+	}
+
+	if x, ok := err.(interface{ As(any) bool }); ok {
+		if target, ok := callAs(x, new(T)); record(StrategyAs, ok) {
+			return target, true
+		}
+
+		if handler.alternate() != nil {
+			if result, ok := handler.handleAs(x); record(StrategyAsAlternate, ok) {
+				return result, true
+			}
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// String returns a multi-line description of the report, one line per visited error.
+func (r Report) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Has[%s]", r.Target)
+
+	if r.Alternate != nil {
+		fmt.Fprintf(&b, " (alternate %s)", r.Alternate)
+	}
+
+	if r.Found {
+		fmt.Fprintf(&b, ": found %T\n", r.Result)
+	} else {
+		b.WriteString(": not found\n")
+	}
+
+	for _, n := range r.Nodes {
+		fmt.Fprintf(&b, "%s%T %q:", strings.Repeat("  ", n.Depth+1), n.Node, errorText(n.Node))
+
+		for _, a := range n.Attempts {
+			outcome := "no"
+			if a.Matched {
+				outcome = "yes"
+			}
+
+			fmt.Fprintf(&b, " %s=%s", a.Strategy, outcome)
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// errorText returns the message of err, guarding against Error methods that panic, e.g., on nil receivers.
+func errorText(err error) (text string) {
+	defer func() {
+		if r := recover(); r != nil {
+			text = fmt.Sprintf("<Error panicked: %v>", r)
+		}
+	}()
+
+	return err.Error()
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", errors.Join(errors.New("first"), MyAsError(8), MyValueError(8)))
+
+	r := Explain[*MyValueError](err)
+
+	if !r.Found {
+		t.Fatalf("Expected to find *MyValueError, but didn't.")
+	}
+
+	if e, ok := r.Result.(*MyValueError); !ok || *e != MyValueError(8) {
+		t.Errorf("Expected *MyValueError(8), but got %v", r.Result)
+	}
+
+	const want = `Has[*errors_test.MyValueError] (alternate errors_test.MyValueError): found *errors_test.MyValueError
+  *fmt.wrapError "wrapped: first\nMyError8\nMyValueError8": assert=no alternate=no
+    *errors.joinError "first\nMyError8\nMyValueError8": assert=no alternate=no
+      *errors.errorString "first": assert=no alternate=no
+      errors_test.MyAsError "MyError8": assert=no alternate=no As=no As-alternate=yes
+`
+
+	if got := r.String(); got != want {
+		t.Errorf("Unexpected report\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestExplainNotFound(t *testing.T) {
+	t.Parallel()
+
+	r := Explain[*MyPointerOnlyError](fmt.Errorf("wrapped: %w", (*MyValueError)(nil)))
+
+	if r.Found || r.Result != nil {
+		t.Errorf("Expected to not find *MyPointerOnlyError, but got %v", r.Result)
+	}
+
+	const want = `Has[*errors_test.MyPointerOnlyError]: not found
+  *fmt.wrapError "wrapped: <nil>": assert=no
+    *errors_test.MyValueError "<Error panicked: value method fillmore-labs.com/exp/errors_test.MyValueError.Error called using nil *MyValueError pointer>": assert=no
+`
+
+	if got := r.String(); got != want {
+		t.Errorf("Unexpected report\n got: %s\nwant: %s", got, want)
+	}
+}
@@ -16,14 +16,40 @@
 
 package errors
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+)
 
+// altHandler resolves pointer-value mismatches for the queried error type T.
+// Implementations are stateless, so a single instance per type is shared by all lookups.
 type altHandler[T error] interface {
 	handleAssert(err error) (T, bool)
+	// matchAssert reports whether handleAssert would succeed, without materializing the result.
+	matchAssert(err error) bool
 	handleAs(x interface{ As(any) bool }) (T, bool)
+	// alternate returns the alternate form of T, or nil when there is none.
+	alternate() reflect.Type
 }
 
-func newAltHandler[T error](ptr *T) altHandler[T] {
+// altHandlers caches the stateless handler for each queried error type.
+// Reads are lock-free once a type has been seen.
+var altHandlers sync.Map // reflect.Type -> altHandler[T]
+
+// altHandlerFor returns the cached handler for T, creating it on first use.
+func altHandlerFor[T error]() altHandler[T] {
+	targetType := reflect.TypeFor[T]()
+
+	if handler, ok := altHandlers.Load(targetType); ok {
+		return handler.(altHandler[T])
+	}
+
+	handler, _ := altHandlers.LoadOrStore(targetType, newAltHandler[T]())
+
+	return handler.(altHandler[T])
+}
+
+func newAltHandler[T error]() altHandler[T] {
 	targetType := reflect.TypeFor[T]()
 
 	isPointerType := targetType.Kind() == reflect.Pointer
@@ -50,7 +76,7 @@ func newAltHandler[T error](ptr *T) altHandler[T] {
 
 	// altType is a pointer type.
 	// handle pointer alternatives for the queried value type
-	return &pointerHandler[T]{altType: altType, ptr: ptr}
+	return &pointerHandler[T]{altType: altType}
 }
 
 var errorType = reflect.TypeOf((*error)(nil)).Elem()
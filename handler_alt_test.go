@@ -0,0 +1,106 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+type (
+	valueReceiverError   struct{ code int }
+	pointerReceiverError struct{ code int }
+)
+
+func (e valueReceiverError) Error() string    { return fmt.Sprintf("value receiver %d", e.code) }
+func (e *pointerReceiverError) Error() string { return fmt.Sprintf("pointer receiver %d", e.code) }
+
+func TestAltHandlerFor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Kinds", func(t *testing.T) {
+		t.Parallel()
+
+		if _, ok := altHandlerFor[*valueReceiverError]().(*valueHandler[*valueReceiverError]); !ok {
+			t.Errorf("Expected *valueHandler for *valueReceiverError")
+		}
+
+		if _, ok := altHandlerFor[valueReceiverError]().(*pointerHandler[valueReceiverError]); !ok {
+			t.Errorf("Expected *pointerHandler for valueReceiverError")
+		}
+
+		if _, ok := altHandlerFor[*pointerReceiverError]().(noneHandler[*pointerReceiverError]); !ok {
+			t.Errorf("Expected noneHandler for *pointerReceiverError")
+		}
+	})
+}
+
+//nolint:paralleltest // testing.AllocsPerRun must not be called from parallel tests
+func TestAltHandlerForAllocs(t *testing.T) {
+	if altHandlerFor[*valueReceiverError]() != altHandlerFor[*valueReceiverError]() {
+		t.Errorf("Expected the same handler for repeated calls")
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = altHandlerFor[*valueReceiverError]() }); allocs != 0 {
+		t.Errorf("Expected no allocations, but got %.1f", allocs)
+	}
+
+	err := fmt.Errorf("wrapped: %w", &pointerReceiverError{code: 1})
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = Has[*valueReceiverError](err) }); allocs != 0 {
+		t.Errorf("Expected no allocations on a miss, but got %.1f", allocs)
+	}
+}
+
+func BenchmarkAltHandlerFor(b *testing.B) {
+	b.ReportAllocs()
+
+	for range b.N {
+		_ = altHandlerFor[*valueReceiverError]()
+	}
+}
+
+func BenchmarkNewAltHandler(b *testing.B) {
+	b.ReportAllocs()
+
+	for range b.N {
+		_ = newAltHandler[*valueReceiverError]()
+	}
+}
+
+func BenchmarkHasMiss(b *testing.B) {
+	err := fmt.Errorf("wrapped: %w", &pointerReceiverError{code: 1})
+
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, ok := Has[*valueReceiverError](err); ok {
+			b.Fatal("Expected not to find *valueReceiverError")
+		}
+	}
+}
+
+func BenchmarkHasPointerForValue(b *testing.B) {
+	err := fmt.Errorf("wrapped: %w", &valueReceiverError{code: 1})
+
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, ok := Has[valueReceiverError](err); !ok {
+			b.Fatal("Expected to find valueReceiverError")
+		}
+	}
+}
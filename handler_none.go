@@ -16,6 +16,8 @@
 
 package errors
 
+import "reflect"
+
 // noneHandler gets chosen when the queried error type has no alternate form.
 type noneHandler[T error] struct{}
 
@@ -31,6 +33,14 @@ func (q noneHandler[T]) handleAssert(_ error) (T, bool) {
 	return q.zero()
 }
 
+func (noneHandler[T]) matchAssert(_ error) bool {
+	return false
+}
+
 func (q noneHandler[T]) handleAs(_ interface{ As(any) bool }) (T, bool) {
 	return q.zero()
 }
+
+func (noneHandler[T]) alternate() reflect.Type {
+	return nil
+}
@@ -23,7 +23,6 @@ import "reflect"
 type pointerHandler[T error] struct {
 	noneHandler[T]
 	altType reflect.Type // alternative pointer type to a value type T, *T = altType
-	ptr     *T
 }
 
 func (h *pointerHandler[T]) handleAssert(err error) (T, bool) {
@@ -41,19 +40,30 @@ func (h *pointerHandler[T]) handleAssert(err error) (T, bool) {
 	return typeAssert[T](val.Elem())
 }
 
-func (h *pointerHandler[T]) handleAs(x interface{ As(any) bool }) (T, bool) {
-	if h.ptr == nil {
-		h.ptr = new(T)
+func (h *pointerHandler[T]) matchAssert(err error) bool {
+	if !reflect.TypeOf(err).AssignableTo(h.altType) {
+		return false
 	}
 
+	// A (*T)(nil) error has no value to dereference.
+	return !reflect.ValueOf(err).IsNil()
+}
+
+func (h *pointerHandler[T]) handleAs(x interface{ As(any) bool }) (T, bool) {
+	var ptr *T
+
 	// When T is a value type (e.g., MyError), some `As` implementations might
 	// expect to populate a pointer to *T, requiring a pointer-to-pointer argument
 	// (e.g., target **MyError).
-	if x.As(&h.ptr) && h.ptr != nil {
+	if x.As(&ptr) && ptr != nil {
 		// If `As` succeeds, ptr is now a valid *T.
 		// We dereference it to return the value type T.
-		return *h.ptr, true
+		return *ptr, true
 	}
 
 	return h.zero()
 }
+
+func (h *pointerHandler[T]) alternate() reflect.Type {
+	return h.altType
+}
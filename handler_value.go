@@ -24,7 +24,6 @@ import "reflect"
 type valueHandler[T error] struct {
 	noneHandler[T]
 	altType reflect.Type // alternative value type to a pointer type T = *altType
-	ptr     any
 }
 
 func (h *valueHandler[T]) handleAssert(err error) (T, bool) {
@@ -41,17 +40,24 @@ func (h *valueHandler[T]) handleAssert(err error) (T, bool) {
 	return typeAssert[T](ptr)
 }
 
+func (h *valueHandler[T]) matchAssert(err error) bool {
+	return reflect.TypeOf(err).AssignableTo(h.altType)
+}
+
 func (h *valueHandler[T]) handleAs(x interface{ As(any) bool }) (T, bool) {
 	// Here, T is a pointer type (*altType). Some `As` implementations might
 	// be designed to populate a value (altType), so they expect a pointer to
 	// that value (*altType).
-	if h.ptr == nil { // Create a new (non-nil) pointer to a zero value of the error's type, *altType = T.
-		h.ptr = reflect.New(h.altType).Interface()
-	}
+	// Create a new (non-nil) pointer to a zero value of the error's type, *altType = T.
+	ptr := reflect.New(h.altType).Interface()
 
-	if x.As(h.ptr) { // And pass that as a target.
-		return h.ptr.(T), true // We can then assert the (non-nil) pointer to T.
+	if x.As(ptr) { // And pass that as a target.
+		return ptr.(T), true // We can then assert the (non-nil) pointer to T.
 	}
 
 	return h.zero()
 }
+
+func (h *valueHandler[T]) alternate() reflect.Type {
+	return h.altType
+}
@@ -29,7 +29,8 @@ package errors
 // the found error is `MyError`, or vice versa), or if the error has a method
 // `As(any) bool` that returns `true`. To accommodate pointer-value mismatches
 // in `As` implementations, `Has` tries different variations of the target type.
-// In the latter case, the `As` method is responsible for the result.
+// In the latter case, the `As` method is responsible for the result. Nil pointer or
+// interface results of an `As` method returning true are ignored.
 //
 // An error type might provide an `As` method, so it can be treated as if it were a
 // different error type.
@@ -46,10 +47,12 @@ func Has[T error](err error) (T, bool) {
 
 		if handler == nil {
 			// Lazily initialize the handler only when a direct type assertion fails.
-			handler = newAltHandler[T](nil)
+			handler = altHandlerFor[T]()
 		}
 
 		if result, ok := handler.handleAssert(err); ok {
+			observeMismatch[T](err, StrategyAlternate)
+
 			return result, true
 		}
 
@@ -59,13 +62,15 @@ func Has[T error](err error) (T, bool) {
 			}
 			// First, try the standard errors.As contract. This works when T matches
 			// the type expected by the As method.
-			if x.As(ptr) {
-				return *ptr, true
+			if result, ok := callAs(x, ptr); ok {
+				return result, true
 			}
 
 			// If the standard call fails, it might be due to a pointer-vs-value mismatch
 			// between T and the type the As method is designed to handle.
 			if result, ok := handler.handleAs(x); ok {
+				observeMismatch[T](err, StrategyAsAlternate)
+
 				return result, true
 			}
 		}
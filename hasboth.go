@@ -0,0 +1,112 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import "reflect"
+
+// HasBoth finds the first error in `err`'s tree that has type `P` or `E`, where `P` is `*E`,
+// and if one is found, returns it as `P` and true. Otherwise, it returns nil and false.
+//
+// HasBoth behaves like [Has] for the pointer type `P`, but since both forms are known at compile
+// time, it uses plain type assertions instead of reflection. Found `E` values are copied into a
+// newly allocated `P`. The type argument `P` is usually inferred:
+//
+//	if kse, ok := HasBoth[aes.KeySizeError](err); ok { /* kse is *aes.KeySizeError */ }
+func HasBoth[E any, P interface {
+	*E
+	error
+}](err error,
+) (P, bool) {
+	for err := range DepthFirstErrorTree(err) {
+		switch e := err.(type) {
+		case P:
+			return e, true
+
+		case E:
+			observeMismatch[P](err, StrategyAlternate)
+
+			return &e, true
+		}
+
+		if x, ok := err.(interface{ As(any) bool }); ok {
+			var p P
+			if result, ok := callAs(x, &p); ok {
+				return result, true
+			}
+
+			// Like Has, only look for the alternate form when it implements error.
+			var e E
+			if isError(e) && x.As(&e) {
+				observeMismatch[P](err, StrategyAsAlternate)
+
+				return &e, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// HasBothValue finds the first error in `err`'s tree that has type `E` or `P`, where `P` is `*E`,
+// and if one is found, returns it as `E` and true. Otherwise, it returns the zero value for `E` and false.
+//
+// HasBothValue behaves like [Has] for the value type `E`, but since both forms are known at compile
+// time, it uses plain type assertions instead of reflection. Found non-nil `P` pointers are dereferenced.
+func HasBothValue[E any, P interface {
+	*E
+	error
+}](err error,
+) (E, bool) {
+	for err := range DepthFirstErrorTree(err) {
+		switch e := err.(type) {
+		case E:
+			return e, true
+
+		case P:
+			if e != nil {
+				observeMismatchType(reflect.TypeFor[E](), err, StrategyAlternate)
+
+				return *e, true
+			}
+		}
+
+		if x, ok := err.(interface{ As(any) bool }); ok {
+			var e E
+			if isError(e) && x.As(&e) {
+				return e, true
+			}
+
+			var ptr P
+			if p, ok := callAs(x, &ptr); ok {
+				observeMismatchType(reflect.TypeFor[E](), err, StrategyAsAlternate)
+
+				return *p, true
+			}
+		}
+	}
+
+	var zero E
+
+	return zero, false
+}
+
+// isError reports whether v implements error, without using reflection.
+func isError(v any) bool {
+	_, ok := v.(error)
+
+	return ok
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"crypto/aes"
+	"fmt"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestHasBoth(t *testing.T) {
+	t.Parallel()
+
+	pointerErr := MyPointerError(8)
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"value", fmt.Errorf("wrapped: %w", MyPointerError(8))},
+		{"pointer", fmt.Errorf("wrapped: %w", &pointerErr)},
+		{"as method", fmt.Errorf("wrapped: %w", MyAsError(8))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if e, ok := HasBoth[MyPointerError](tt.err); !ok {
+				t.Errorf("Expected to find *MyPointerError, but didn't.")
+			} else if *e != MyPointerError(8) {
+				t.Errorf("Expected *MyPointerError(8), but got %d", int(*e))
+			}
+
+			if e, ok := HasBothValue[MyPointerError](tt.err); !ok {
+				t.Errorf("Expected to find MyPointerError, but didn't.")
+			} else if e != MyPointerError(8) {
+				t.Errorf("Expected MyPointerError(8), but got %d", int(e))
+			}
+		})
+	}
+}
+
+func TestHasBothNotFound(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", MyValueError(8))
+
+	if _, ok := HasBoth[MyPointerOnlyError](err); ok {
+		t.Errorf("Expected to not find *MyPointerOnlyError, but did.")
+	}
+
+	if _, ok := HasBothValue[MyValueError]((*MyValueError)(nil)); ok {
+		t.Errorf("Expected to not find MyValueError in nil pointer, but did.")
+	}
+}
+
+// recordingAsError records the target types its As method is called with.
+type recordingAsError struct{ targets *[]string }
+
+func (recordingAsError) Error() string { return "recording" }
+
+func (e recordingAsError) As(target any) bool {
+	*e.targets = append(*e.targets, fmt.Sprintf("%T", target))
+
+	return false
+}
+
+func TestHasBothAsTargets(t *testing.T) {
+	t.Parallel()
+
+	var targets []string
+
+	err := recordingAsError{targets: &targets}
+
+	if _, ok := HasBoth[MyPointerOnlyError](err); ok {
+		t.Errorf("Expected to not find *MyPointerOnlyError, but did.")
+	}
+
+	if _, ok := HasBothValue[MyPointerOnlyError](err); ok {
+		t.Errorf("Expected to not find MyPointerOnlyError, but did.")
+	}
+
+	// Like Has, the non-error form MyPointerOnlyError is never passed to As.
+	const want = "**errors_test.MyPointerOnlyError"
+	if len(targets) != 2 || targets[0] != want || targets[1] != want {
+		t.Errorf("Expected As calls with %s only, but got %v", want, targets)
+	}
+}
+
+func TestHasBothLyingAs(t *testing.T) {
+	t.Parallel()
+
+	// MyLyingAsError returns true, but sets a nil *MyPointerOnlyError.
+	if e, ok := HasBoth[MyPointerOnlyError](MyLyingAsError{}); ok {
+		t.Errorf("Expected to reject a nil result, but got %v", e)
+	}
+
+	if e, ok := HasBothValue[MyPointerOnlyError](MyLyingAsError{}); ok {
+		t.Errorf("Expected to reject a nil result, but got %v", e)
+	}
+}
+
+var benchKeySizeErr = func() error {
+	_, err := aes.NewCipher([]byte("My kung fu is better than yours"))
+
+	return fmt.Errorf("cipher: %w", err)
+}()
+
+func BenchmarkHasBoth(b *testing.B) {
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, ok := HasBoth[aes.KeySizeError](benchKeySizeErr); !ok {
+			b.Fatal("Expected to find *aes.KeySizeError")
+		}
+	}
+}
+
+func BenchmarkHasReflect(b *testing.B) {
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, ok := Has[*aes.KeySizeError](benchKeySizeErr); !ok {
+			b.Fatal("Expected to find *aes.KeySizeError")
+		}
+	}
+}
+
+func BenchmarkHasBothValue(b *testing.B) {
+	valueErr := MyValueError(8)
+	err := fmt.Errorf("wrapped: %w", &valueErr)
+
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, ok := HasBothValue[MyValueError](err); !ok {
+			b.Fatal("Expected to find MyValueError")
+		}
+	}
+}
+
+func BenchmarkHasReflectValue(b *testing.B) {
+	valueErr := MyValueError(8)
+	err := fmt.Errorf("wrapped: %w", &valueErr)
+
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, ok := Has[MyValueError](err); !ok {
+			b.Fatal("Expected to find MyValueError")
+		}
+	}
+}
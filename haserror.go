@@ -30,7 +30,7 @@ package errors
 //   - The error's concrete value is assignable to `T`.
 //   - The error has a method `As(any) bool`, and calling `As` with a pointer to a
 //     value of type `T` returns `true`. In this case, the `As` method is
-//     responsible for the result.
+//     responsible for the result. Nil pointer or interface results are ignored.
 func HasError[T error](err error) (T, bool) {
 	var ptr *T
 
@@ -44,8 +44,8 @@ func HasError[T error](err error) (T, bool) {
 				ptr = new(T)
 			}
 			// Try the standard errors.As contract.
-			if x.As(ptr) {
-				return *ptr, true
+			if result, ok := callAs(x, ptr); ok {
+				return result, true
 			}
 		}
 	}
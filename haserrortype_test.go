@@ -0,0 +1,46 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestHasErrorType(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", MyValueError(8))
+
+	if !HasErrorType[MyValueError](err) {
+		t.Errorf("Expected to find MyValueError, but didn't.")
+	}
+
+	if HasErrorType[*MyValueError](err) {
+		t.Errorf("Expected to not find *MyValueError, but did.")
+	}
+
+	if !HasErrorType[*MyPointerError](MyAsError(8)) {
+		t.Errorf("Expected to find *MyPointerError via As, but didn't.")
+	}
+
+	if HasErrorType[MyPointerError](MyAsError(8)) {
+		t.Errorf("Expected to not find MyPointerError via As, but did.")
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import "reflect"
+
+// HasLike finds the first error in `err`'s tree that has type `T` and resembles
+// `template`, and if one is found, returns that error and true. Otherwise, it
+// returns the zero value for `T` and false.
+//
+// Errors of type `T` are found the same way [Has] finds them, including
+// pointer-value mismatches and `As(any) bool` methods.
+//
+// An error resembles `template` when every non-zero field of `template` equals
+// the corresponding field of the error. Nested structs, pointers, arrays and
+// interfaces are compared recursively by the same rule, so zero fields at any
+// depth act as wildcards. A nil pointer template matches every error of type `T`.
+//
+//	if pe, ok := HasLike(err, &fs.PathError{Op: "open"}); ok { /* ... */ }
+func HasLike[T error](err error, template T) (T, bool) {
+	var (
+		handler altHandler[T]
+		ptr     *T
+	)
+
+	tmpl := reflect.ValueOf(&template).Elem()
+	like := func(candidate T) bool {
+		return resembles(reflect.ValueOf(&candidate).Elem(), tmpl)
+	}
+
+	for err := range DepthFirstErrorTree(err) {
+		if target, ok := err.(T); ok && like(target) {
+			return target, true
+		}
+
+		if handler == nil {
+			handler = altHandlerFor[T]()
+		}
+
+		if result, ok := handler.handleAssert(err); ok && like(result) {
+			observeMismatch[T](err, StrategyAlternate)
+
+			return result, true
+		}
+
+		if x, ok := err.(interface{ As(any) bool }); ok {
+			if ptr == nil {
+				ptr = new(T)
+			}
+
+			if result, ok := callAs(x, ptr); ok && like(result) {
+				return result, true
+			}
+
+			if result, ok := handler.handleAs(x); ok && like(result) {
+				observeMismatch[T](err, StrategyAsAlternate)
+
+				return result, true
+			}
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// resembles reports whether v equals every non-zero part of tmpl. Both values must have the same type.
+func resembles(v, tmpl reflect.Value) bool {
+	if tmpl.IsZero() {
+		return true
+	}
+
+	switch tmpl.Kind() {
+	case reflect.Pointer:
+		return !v.IsNil() && (v.Pointer() == tmpl.Pointer() || resembles(v.Elem(), tmpl.Elem()))
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+
+		ve, te := v.Elem(), tmpl.Elem()
+
+		return ve.Type() == te.Type() && resembles(ve, te)
+
+	case reflect.Struct:
+		for i := range tmpl.NumField() {
+			if !resembles(v.Field(i), tmpl.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Array:
+		for i := range tmpl.Len() {
+			if !resembles(v.Index(i), tmpl.Index(i)) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Slice:
+		if v.Len() != tmpl.Len() {
+			return false
+		}
+
+		for i := range tmpl.Len() {
+			if !resembles(v.Index(i), tmpl.Index(i)) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Map:
+		for iter := tmpl.MapRange(); iter.Next(); {
+			val := v.MapIndex(iter.Key())
+			if !val.IsValid() || !resembles(val, iter.Value()) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return v.Pointer() == tmpl.Pointer()
+
+	default:
+		return v.Equal(tmpl)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestHasLike(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", &fs.PathError{Op: "stat", Path: "/a", Err: fs.ErrNotExist})
+	err = fmt.Errorf("%w, %w", err, &fs.PathError{Op: "open", Path: "/b", Err: fs.ErrPermission})
+
+	tests := []struct {
+		name     string
+		template *fs.PathError
+		path     string
+		found    bool
+	}{
+		{"nil template", nil, "/a", true},
+		{"zero template", &fs.PathError{}, "/a", true},
+		{"first field", &fs.PathError{Op: "stat"}, "/a", true},
+		{"second node", &fs.PathError{Op: "open"}, "/b", true},
+		{"interface field", &fs.PathError{Err: fs.ErrPermission}, "/b", true},
+		{"all fields", &fs.PathError{Op: "open", Path: "/b", Err: fs.ErrPermission}, "/b", true},
+		{"no match", &fs.PathError{Op: "open", Path: "/a"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pe, ok := HasLike(err, tt.template)
+
+			switch {
+			case ok != tt.found:
+				t.Errorf("Expected found == %t, but got %t", tt.found, ok)
+			case ok && pe.Path != tt.path:
+				t.Errorf("Expected path %q, but got %q", tt.path, pe.Path)
+			}
+		})
+	}
+}
+
+func TestHasLikeMismatch(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", MyStructError{Code: 404, Detail: MyDetail{Reason: "gone", Retry: true}})
+
+	if e, ok := HasLike(err, &MyStructError{Code: 404}); !ok {
+		t.Errorf("Expected to find *MyStructError with code 404, but didn't.")
+	} else if e.Detail.Reason != "gone" {
+		t.Errorf("Expected reason %q, but got %q", "gone", e.Detail.Reason)
+	}
+
+	if _, ok := HasLike(err, MyStructError{Detail: MyDetail{Retry: true}}); !ok {
+		t.Errorf("Expected to find MyStructError with nested Retry, but didn't.")
+	}
+
+	if _, ok := HasLike(err, MyStructError{Code: 404, Detail: MyDetail{Reason: "moved"}}); ok {
+		t.Errorf("Expected to not find MyStructError with reason %q, but did.", "moved")
+	}
+}
+
+func TestHasLikeAs(t *testing.T) {
+	t.Parallel()
+
+	err := MyAsError(8)
+
+	if _, ok := HasLike(err, MyPointerError(8)); !ok {
+		t.Errorf("Expected to find MyPointerError(8), but didn't.")
+	}
+
+	if _, ok := HasLike(err, MyPointerError(9)); ok {
+		t.Errorf("Expected to not find MyPointerError(9), but did.")
+	}
+}
+
+type (
+	MyStructError struct {
+		Code   int
+		Detail MyDetail
+	}
+
+	MyDetail struct {
+		Reason string
+		Retry  bool
+	}
+)
+
+func (e MyStructError) Error() string {
+	return fmt.Sprintf("MyStructError %d: %s", e.Code, e.Detail.Reason)
+}
+
+var _ error = MyStructError{}
@@ -0,0 +1,104 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrNotFound is returned by [HasStrict] when neither the queried type nor its alternate form is found.
+var ErrNotFound = errors.New("errors: type not found")
+
+// MismatchError is returned by [HasStrict] when the error tree contains only the pointer-value
+// alternate form of the queried type.
+type MismatchError struct {
+	Requested reflect.Type // The queried type
+	Found     reflect.Type // The alternate form of the queried type that was found
+	Node      reflect.Type // The dynamic type of the error in the tree, differs from Found for As methods
+	Path      []error      // The errors from the root of the tree to the found error
+}
+
+func (e *MismatchError) Error() string {
+	path := make([]string, len(e.Path))
+	for i, err := range e.Path {
+		path[i] = reflect.TypeOf(err).String()
+	}
+
+	return fmt.Sprintf("errors: %s requested, but only %s found at %s", e.Requested, e.Found, strings.Join(path, " > "))
+}
+
+// HasStrict finds the first error in `err`'s tree that is of type `T`, as [HasError] defines it.
+// If a matching error is found, it is returned with a nil error.
+//
+// Otherwise, HasStrict returns a [*MismatchError] describing the first error in the tree that
+// [Has] would have accepted through a pointer-value mismatch, or [ErrNotFound] if there is none.
+// It is intended for tests and CI checks, where mismatches should be diagnosed instead of resolved.
+func HasStrict[T error](err error) (T, error) {
+	var (
+		ptr      *T
+		mismatch *MismatchError
+		handler  = altHandlerFor[T]()
+	)
+
+	for node, path := range depthFirstWithPath(err) {
+		if target, ok := node.(T); ok {
+			return target, nil
+		}
+
+		x, hasAs := node.(interface{ As(any) bool })
+		if hasAs {
+			if ptr == nil {
+				ptr = new(T)
+			}
+
+			if result, ok := callAs(x, ptr); ok {
+				return result, nil
+			}
+		}
+
+		if mismatch != nil {
+			continue
+		}
+
+		// Remember the first error Has would have accepted, in case there is no strict match.
+		matched := handler.matchAssert(node)
+		if !matched && hasAs {
+			_, matched = handler.handleAs(x)
+		}
+
+		if matched {
+			mismatch = &MismatchError{
+				Requested: reflect.TypeFor[T](),
+				Found:     handler.alternate(),
+				Node:      reflect.TypeOf(node),
+				Path:      append(slices.Clone(path), node),
+			}
+		}
+	}
+
+	var zero T
+
+	if mismatch != nil {
+		return zero, mismatch
+	}
+
+	return zero, ErrNotFound
+}
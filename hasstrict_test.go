@@ -0,0 +1,95 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestHasStrict(t *testing.T) {
+	t.Parallel()
+
+	valueErr := MyValueError(8)
+	err := fmt.Errorf("wrapped: %w", errors.Join(&valueErr, MyValueError(9)))
+
+	if e, err := HasStrict[MyValueError](err); err != nil {
+		t.Errorf("Expected to find MyValueError, but got %v", err)
+	} else if e != MyValueError(9) {
+		t.Errorf("Expected MyValueError(9), but got %d", int(e))
+	}
+
+	if e, err := HasStrict[*MyPointerError](MyAsError(8)); err != nil {
+		t.Errorf("Expected to find *MyPointerError via As, but got %v", err)
+	} else if *e != MyPointerError(8) {
+		t.Errorf("Expected *MyPointerError(8), but got %d", int(*e))
+	}
+}
+
+func TestHasStrictMismatch(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", errors.Join(errors.New("first"), MyValueError(8)))
+
+	_, err = HasStrict[*MyValueError](err)
+
+	mismatch, ok := HasError[*MismatchError](err)
+	if !ok {
+		t.Fatalf("Expected *MismatchError, but got %v", err)
+	}
+
+	if mismatch.Requested != reflect.TypeFor[*MyValueError]() || mismatch.Found != reflect.TypeFor[MyValueError]() {
+		t.Errorf("Unexpected types %s and %s", mismatch.Requested, mismatch.Found)
+	}
+
+	if len(mismatch.Path) != 3 || mismatch.Path[2] != MyValueError(8) {
+		t.Errorf("Expected path of length 3 ending with MyValueError(8), but got %v", mismatch.Path)
+	}
+
+	const want = "errors: *errors_test.MyValueError requested, but only errors_test.MyValueError found at " +
+		"*fmt.wrapError > *errors.joinError > errors_test.MyValueError"
+	if got := err.Error(); got != want {
+		t.Errorf("Unexpected message\n got: %s\nwant: %s", got, want)
+	}
+
+	if mismatch.Node != reflect.TypeFor[MyValueError]() {
+		t.Errorf("Expected node type MyValueError, but got %s", mismatch.Node)
+	}
+
+	_, err = HasStrict[MyPointerError](MyAsError(8))
+
+	mismatch, ok = HasError[*MismatchError](err)
+	if !ok {
+		t.Fatalf("Expected *MismatchError for As alternate, but got %v", err)
+	}
+
+	if mismatch.Found != reflect.TypeFor[*MyPointerError]() || mismatch.Node != reflect.TypeFor[MyAsError]() {
+		t.Errorf("Expected *MyPointerError found through MyAsError, but got %s through %s", mismatch.Found, mismatch.Node)
+	}
+}
+
+func TestHasStrictNotFound(t *testing.T) {
+	t.Parallel()
+
+	if _, err := HasStrict[*MyPointerOnlyError](MyValueError(8)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, but got %v", err)
+	}
+}
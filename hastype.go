@@ -0,0 +1,64 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+// HasType reports whether `err`'s tree contains an error of type `T`, as [Has] defines it.
+//
+// Unlike `_, ok := Has[T](err)`, HasType does not materialize the found error, so pointer-value
+// mismatches are detected without allocating. Only errors with an `As(any) bool` method need
+// an allocated target.
+func HasType[T error](err error) bool {
+	var (
+		handler altHandler[T]
+		ptr     *T
+	)
+
+	for err := range DepthFirstErrorTree(err) {
+		if _, ok := err.(T); ok {
+			return true
+		}
+
+		if handler == nil {
+			// Lazily initialize the handler only when a direct type assertion fails.
+			handler = altHandlerFor[T]()
+		}
+
+		if handler.matchAssert(err) {
+			observeMismatch[T](err, StrategyAlternate)
+
+			return true
+		}
+
+		if x, ok := err.(interface{ As(any) bool }); ok {
+			if ptr == nil {
+				ptr = new(T)
+			}
+
+			if _, ok := callAs(x, ptr); ok {
+				return true
+			}
+
+			if _, ok := handler.handleAs(x); ok {
+				observeMismatch[T](err, StrategyAsAlternate)
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestHasType(t *testing.T) {
+	t.Parallel()
+
+	pointerErr := MyPointerError(8)
+	err := fmt.Errorf("%w, %w", MyValueError(8), &pointerErr)
+
+	if !HasType[*MyValueError](err) {
+		t.Errorf("Expected to find *MyValueError, but didn't.")
+	}
+
+	if !HasType[MyPointerError](err) {
+		t.Errorf("Expected to find MyPointerError, but didn't.")
+	}
+
+	if !HasType[MyPointerError](MyAsError(8)) {
+		t.Errorf("Expected to find MyPointerError via As, but didn't.")
+	}
+
+	if HasType[MyValueError]((*MyValueError)(nil)) {
+		t.Errorf("Expected to not find MyValueError in nil, but did.")
+	}
+
+	if HasType[*MyPointerOnlyError](err) {
+		t.Errorf("Expected to not find *MyPointerOnlyError, but did.")
+	}
+}
+
+//nolint:paralleltest // testing.AllocsPerRun must not be called from parallel tests
+func TestHasTypeAllocs(t *testing.T) {
+	pointerErr := MyPointerError(8)
+	err := fmt.Errorf("%w, %w", MyValueError(8), &pointerErr)
+	missErr := fmt.Errorf("wrapped: %w", MyValueError(8))
+
+	tests := []struct {
+		name   string
+		check  func() bool
+		allocs float64
+	}{
+		{"value for pointer", func() bool { return HasType[*MyValueError](err) }, 0},
+		{"pointer for value", func() bool { return HasType[MyPointerError](err) }, 0},
+		{"miss", func() bool { return !HasType[*MyPointerOnlyError](missErr) }, 0},
+		{"strict hit", func() bool { return HasErrorType[MyValueError](err) }, 0},
+		{"strict miss", func() bool { return !HasErrorType[*MyValueError](missErr) }, 0},
+		// The target, the pointer-to-pointer target and the result allocated by MyAsError.As
+		{"as method", func() bool { return HasType[MyPointerError](MyAsError(8)) }, 3},
+	}
+
+	for _, tt := range tests {
+		if !tt.check() {
+			t.Errorf("%s: unexpected result", tt.name)
+		}
+
+		if allocs := testing.AllocsPerRun(100, func() { tt.check() }); allocs != tt.allocs {
+			t.Errorf("%s: expected %.0f allocations, but got %.1f", tt.name, tt.allocs, allocs)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import "reflect"
+
+// Matcher describes errors to look for in an error tree. Unlike the type parameter of [Has],
+// a Matcher is a value that can be stored in slices and maps.
+//
+// Matchers are created with [TypeOf], [Sentinel] and [Func], and combined with [And], [Or],
+// [Not] and [Under]. They are evaluated by [Match] and [MatchAll] and are safe for concurrent use.
+type Matcher interface {
+	// matchNode reports whether node matches and returns the matched error.
+	// path holds the ancestors of node, starting with the root of the tree.
+	matchNode(node error, path []error) (error, bool)
+}
+
+// Match finds the first error in `err`'s tree matched by `m`, and if one is found,
+// returns the matched error and true. Otherwise, it returns nil and false.
+//
+// The tree is traversed depth-first, like [DepthFirstErrorTree] does.
+func Match(err error, m Matcher) (error, bool) {
+	for node, path := range depthFirstWithPath(err) {
+		if result, ok := m.matchNode(node, path); ok {
+			return result, true
+		}
+	}
+
+	return nil, false
+}
+
+// MatchAll returns all errors in `err`'s tree matched by `m`, in depth-first order.
+func MatchAll(err error, m Matcher) []error {
+	var results []error
+
+	for node, path := range depthFirstWithPath(err) {
+		if result, ok := m.matchNode(node, path); ok {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// TypeOf returns a [Matcher] for errors of type `T`, including pointer-value mismatches and
+// `As(any) bool` methods, as [Has] defines them. The matched error is the `T` value.
+func TypeOf[T error]() Matcher {
+	return typeMatcher[T]{}
+}
+
+type typeMatcher[T error] struct{}
+
+func (typeMatcher[T]) matchNode(err error, _ []error) (error, bool) {
+	if target, ok := err.(T); ok {
+		return target, true
+	}
+
+	handler := altHandlerFor[T]()
+
+	if result, ok := handler.handleAssert(err); ok {
+		observeMismatch[T](err, StrategyAlternate)
+
+		return result, true
+	}
+
+	if x, ok := err.(interface{ As(any) bool }); ok {
+		if target, ok := callAs(x, new(T)); ok {
+			return target, true
+		}
+
+		if result, ok := handler.handleAs(x); ok {
+			observeMismatch[T](err, StrategyAsAlternate)
+
+			return result, true
+		}
+	}
+
+	return nil, false
+}
+
+// Sentinel returns a [Matcher] for errors equal to `target` or having a method `Is(error) bool`
+// that returns true for `target`, the same way [errors.Is] compares errors.
+func Sentinel(target error) Matcher {
+	return sentinelMatcher{target: target, comparable: target != nil && reflect.TypeOf(target).Comparable()}
+}
+
+type sentinelMatcher struct {
+	target     error
+	comparable bool
+}
+
+func (m sentinelMatcher) matchNode(err error, _ []error) (error, bool) {
+	if m.comparable && err == m.target {
+		return err, true
+	}
+
+	if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(m.target) {
+		return err, true
+	}
+
+	return nil, false
+}
+
+// Func returns a [Matcher] for errors where `pred` returns true.
+func Func(pred func(error) bool) Matcher {
+	return funcMatcher(pred)
+}
+
+type funcMatcher func(error) bool
+
+func (f funcMatcher) matchNode(err error, _ []error) (error, bool) {
+	if f(err) {
+		return err, true
+	}
+
+	return nil, false
+}
+
+// And returns a [Matcher] for errors matched by all of `ms`. The matched error is the result of
+// the first matcher, or the error itself when `ms` is empty.
+func And(ms ...Matcher) Matcher {
+	return andMatcher(ms)
+}
+
+type andMatcher []Matcher
+
+func (a andMatcher) matchNode(err error, path []error) (error, bool) {
+	result := err
+
+	for i, m := range a {
+		r, ok := m.matchNode(err, path)
+		if !ok {
+			return nil, false
+		}
+
+		if i == 0 {
+			result = r
+		}
+	}
+
+	return result, true
+}
+
+// Or returns a [Matcher] for errors matched by any of `ms`. The matched error is the result of
+// the first matcher that matches.
+func Or(ms ...Matcher) Matcher {
+	return orMatcher(ms)
+}
+
+type orMatcher []Matcher
+
+func (o orMatcher) matchNode(err error, path []error) (error, bool) {
+	for _, m := range o {
+		if result, ok := m.matchNode(err, path); ok {
+			return result, true
+		}
+	}
+
+	return nil, false
+}
+
+// Not returns a [Matcher] for errors not matched by `m`.
+func Not(m Matcher) Matcher {
+	return notMatcher{m}
+}
+
+type notMatcher struct{ m Matcher }
+
+func (n notMatcher) matchNode(err error, path []error) (error, bool) {
+	if _, ok := n.m.matchNode(err, path); ok {
+		return nil, false
+	}
+
+	return err, true
+}
+
+// Under returns a [Matcher] for errors matched by `child` that are wrapped, directly or
+// indirectly, by an error matched by `parent`. The matched error is the result of `child`.
+func Under(parent, child Matcher) Matcher {
+	return underMatcher{parent: parent, child: child}
+}
+
+type underMatcher struct{ parent, child Matcher }
+
+func (u underMatcher) matchNode(err error, path []error) (error, bool) {
+	result, ok := u.child.matchNode(err, path)
+	if !ok {
+		return nil, false
+	}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		if _, ok := u.parent.matchNode(path[i], path[:i]); ok {
+			return result, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	pathErr := &fs.PathError{Op: "open", Path: "/a", Err: fs.ErrNotExist}
+	err := errors.Join(
+		fmt.Errorf("reading: %w", io.ErrUnexpectedEOF),
+		fmt.Errorf("config: %w", pathErr),
+		MyValueError(8),
+	)
+
+	isOpen := Func(func(err error) bool {
+		pe, ok := err.(*fs.PathError)
+
+		return ok && pe.Op == "open"
+	})
+
+	tests := []struct {
+		name    string
+		matcher Matcher
+		want    error
+	}{
+		{"type", TypeOf[*fs.PathError](), pathErr},
+		{"pointer-value", TypeOf[*MyValueError](), nil},
+		{"sentinel", Sentinel(io.ErrUnexpectedEOF), io.ErrUnexpectedEOF},
+		{"and", And(TypeOf[*fs.PathError](), isOpen), pathErr},
+		{"and mismatch", And(TypeOf[*fs.PathError](), Not(isOpen)), nil},
+		{"or", Or(Sentinel(io.EOF), TypeOf[MyValueError]()), MyValueError(8)},
+		{"under", Under(TypeOf[*fs.PathError](), Sentinel(fs.ErrNotExist)), fs.ErrNotExist},
+		{"not under", Under(TypeOf[*fs.PathError](), Sentinel(io.ErrUnexpectedEOF)), nil},
+		{"missing", Sentinel(io.EOF), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := Match(err, tt.matcher)
+
+			switch {
+			case tt.name == "pointer-value":
+				if e, _ := got.(*MyValueError); !ok || e == nil || *e != MyValueError(8) {
+					t.Errorf("Expected to find *MyValueError(8), but got %v", got)
+				}
+
+			case ok != (tt.want != nil):
+				t.Errorf("Expected match == %t, but got %t", tt.want != nil, ok)
+
+			case got != tt.want:
+				t.Errorf("Expected %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchAll(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Join(MyValueError(1), fmt.Errorf("wrapped: %w", MyValueError(2)), MyPointerError(3))
+
+	matchers := map[string]Matcher{
+		"values": TypeOf[MyValueError](),
+		"errors": Or(TypeOf[MyValueError](), TypeOf[MyPointerError]()),
+	}
+
+	if got := MatchAll(err, matchers["values"]); len(got) != 2 || got[0] != MyValueError(1) || got[1] != MyValueError(2) {
+		t.Errorf("Expected [MyValueError(1) MyValueError(2)], but got %v", got)
+	}
+
+	if got := MatchAll(err, matchers["errors"]); len(got) != 3 {
+		t.Errorf("Expected 3 matches, but got %v", got)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// Mismatch describes a lookup that succeeded only by resolving a pointer-value mismatch.
+type Mismatch struct {
+	Requested reflect.Type  // The queried type
+	Found     reflect.Type  // The dynamic type of the matched error in the tree
+	Strategy  Strategy      // [StrategyAlternate] or [StrategyAsAlternate]
+	Caller    runtime.Frame // The first caller outside this package
+}
+
+// String returns a description of the mismatch.
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s:%d: %s requested, but matched %s via %s", m.Caller.File, m.Caller.Line, m.Requested, m.Found, m.Strategy)
+}
+
+var mismatchHook atomic.Pointer[func(Mismatch)]
+
+// SetMismatchHook installs a function that is called whenever a lookup succeeds only through the
+// pointer-value alternate form of the queried type, and returns the previously installed hook.
+// A nil hook disables reporting.
+//
+// Reporting lookups are [Has], [As], [HasType], [HasLike], [HasBoth], [HasBothValue], [AsBoth],
+// [AsBothValue], [SnapshotHas], [SnapshotAs], the safe variants of [Has] and [As], [Matcher] values
+// created by [TypeOf], and [TypeSet] lookups. Strict lookups like [HasError] never resolve mismatches.
+//
+// The hook is global and may be called concurrently. It is intended to find inconsistent wrapping
+// of errors during tests or in production logging, while lookups continue to succeed.
+func SetMismatchHook(hook func(Mismatch)) (previous func(Mismatch)) {
+	var old *func(Mismatch)
+	if hook == nil {
+		old = mismatchHook.Swap(nil)
+	} else {
+		old = mismatchHook.Swap(&hook)
+	}
+
+	if old == nil {
+		return nil
+	}
+
+	return *old
+}
+
+// observeMismatch reports a successful alternate match of node to the installed hook, if any.
+func observeMismatch[T error](node error, s Strategy) {
+	if mismatchHook.Load() == nil {
+		return
+	}
+
+	observeMismatchType(reflect.TypeFor[T](), node, s)
+}
+
+// observeMismatchType is [observeMismatch] for a requested type known at run time.
+func observeMismatchType(requested reflect.Type, node error, s Strategy) {
+	hook := mismatchHook.Load()
+	if hook == nil {
+		return
+	}
+
+	(*hook)(Mismatch{
+		Requested: requested,
+		Found:     reflect.TypeOf(node),
+		Strategy:  s,
+		Caller:    externalCaller(),
+	})
+}
+
+// packagePrefix is the prefix of the qualified names of this package's functions.
+var packagePrefix = reflect.TypeFor[Mismatch]().PkgPath() + "."
+
+// externalCaller returns the first frame on the call stack outside this package.
+func externalCaller() runtime.Frame {
+	var pcs [16]uintptr
+
+	n := runtime.Callers(3, pcs[:]) // Skip runtime.Callers, externalCaller and observeMismatchType
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) || !more {
+			return frame
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+//nolint:paralleltest // The mismatch hook is global
+func TestMismatchHook(t *testing.T) {
+	var mismatches []Mismatch
+
+	previous := SetMismatchHook(func(m Mismatch) { mismatches = append(mismatches, m) })
+	defer SetMismatchHook(previous)
+
+	err := fmt.Errorf("wrapped: %w", MyValueError(8))
+
+	if _, ok := Has[MyValueError](err); !ok {
+		t.Fatalf("Expected to find MyValueError, but didn't.")
+	}
+
+	if len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches for a direct match, but got %v", mismatches)
+	}
+
+	if _, ok := Has[*MyValueError](err); !ok {
+		t.Fatalf("Expected to find *MyValueError, but didn't.")
+	}
+
+	var target MyPointerError
+	if !As(MyAsError(8), &target) {
+		t.Fatalf("Expected to find MyPointerError, but didn't.")
+	}
+
+	if len(mismatches) != 2 {
+		t.Fatalf("Expected 2 mismatches, but got %v", mismatches)
+	}
+
+	m := mismatches[0]
+	if m.Requested != reflect.TypeFor[*MyValueError]() || m.Found != reflect.TypeFor[MyValueError]() || m.Strategy != StrategyAlternate {
+		t.Errorf("Unexpected mismatch %v", m)
+	}
+
+	if filepath.Base(m.Caller.File) != "mismatch_test.go" || m.Caller.Function != "fillmore-labs.com/exp/errors_test.TestMismatchHook" {
+		t.Errorf("Expected caller in TestMismatchHook, but got %s in %s", m.Caller.File, m.Caller.Function)
+	}
+
+	if m := mismatches[1]; m.Found != reflect.TypeFor[MyAsError]() || m.Strategy != StrategyAsAlternate {
+		t.Errorf("Unexpected mismatch %v", m)
+	}
+}
+
+//nolint:paralleltest // The mismatch hook is global
+func TestMismatchHookCoverage(t *testing.T) {
+	var mismatches []Mismatch
+
+	previous := SetMismatchHook(func(m Mismatch) { mismatches = append(mismatches, m) })
+	defer SetMismatchHook(previous)
+
+	pointerSet := NewTypeSet(SetTypeOf[*MyValueError]())
+	valueSet := NewTypeSet(SetTypeOf[MyValueError]())
+
+	tests := []struct {
+		name      string
+		lookup    func() bool
+		requested reflect.Type
+		found     reflect.Type
+		strategy  Strategy
+	}{
+		{"TypeOf", func() bool {
+			_, ok := Match(MyValueError(1), TypeOf[*MyValueError]())
+
+			return ok
+		}, reflect.TypeFor[*MyValueError](), reflect.TypeFor[MyValueError](), StrategyAlternate},
+		{"TypeOfAs", func() bool {
+			_, ok := Match(MyAsError(1), TypeOf[*MyValueError]())
+
+			return ok
+		}, reflect.TypeFor[*MyValueError](), reflect.TypeFor[MyAsError](), StrategyAsAlternate},
+		{"TypeSet", func() bool {
+			_, ok := pointerSet.First(MyValueError(1))
+
+			return ok
+		}, reflect.TypeFor[*MyValueError](), reflect.TypeFor[MyValueError](), StrategyAlternate},
+		{"TypeSetAs", func() bool {
+			_, ok := pointerSet.First(MyAsError(1))
+
+			return ok
+		}, reflect.TypeFor[*MyValueError](), reflect.TypeFor[MyAsError](), StrategyAsAlternate},
+		{"TypeSetValue", func() bool {
+			v := MyValueError(1)
+			m := valueSet.Find(&v)
+
+			return m[0].Kind == MatchAlternate
+		}, reflect.TypeFor[MyValueError](), reflect.TypeFor[*MyValueError](), StrategyAlternate},
+		{"HasBoth", func() bool {
+			_, ok := HasBoth[MyValueError](MyValueError(1))
+
+			return ok
+		}, reflect.TypeFor[*MyValueError](), reflect.TypeFor[MyValueError](), StrategyAlternate},
+		{"HasBothAs", func() bool {
+			_, ok := HasBoth[MyValueError](MyAsError(1))
+
+			return ok
+		}, reflect.TypeFor[*MyValueError](), reflect.TypeFor[MyAsError](), StrategyAsAlternate},
+		{"HasBothValue", func() bool {
+			v := MyValueError(1)
+			_, ok := HasBothValue[MyValueError](&v)
+
+			return ok
+		}, reflect.TypeFor[MyValueError](), reflect.TypeFor[*MyValueError](), StrategyAlternate},
+		{"AsBoth", func() bool {
+			var target *MyValueError
+
+			return AsBoth(MyValueError(1), &target)
+		}, reflect.TypeFor[*MyValueError](), reflect.TypeFor[MyValueError](), StrategyAlternate},
+		{"AsBothValue", func() bool {
+			v := MyValueError(1)
+
+			var target MyValueError
+
+			return AsBothValue[MyValueError, *MyValueError](&v, &target)
+		}, reflect.TypeFor[MyValueError](), reflect.TypeFor[*MyValueError](), StrategyAlternate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatches = nil
+
+			if !tt.lookup() {
+				t.Fatalf("Expected to find %v, but didn't.", tt.requested)
+			}
+
+			if len(mismatches) != 1 {
+				t.Fatalf("Expected 1 mismatch, but got %v", mismatches)
+			}
+
+			m := mismatches[0]
+			if m.Requested != tt.requested || m.Found != tt.found || m.Strategy != tt.strategy {
+				t.Errorf("Expected mismatch of %v for %v with %v, but got %v", tt.found, tt.requested, tt.strategy, m)
+			}
+
+			if filepath.Base(m.Caller.File) != "mismatch_test.go" {
+				t.Errorf("Expected caller in mismatch_test.go, but got %s in %s", m.Caller.File, m.Caller.Function)
+			}
+		})
+	}
+}
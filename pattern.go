@@ -0,0 +1,304 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Pattern is a compiled description of a shape in an error tree.
+//
+// A pattern is a sequence of elements separated by `>`, where `A > B` requires `B` to be
+// a direct child of `A` (as returned by `Unwrap() error` or `Unwrap() []error`), and
+// `A > ... > B` requires `B` to be a descendant of `A` at any depth:
+//
+//	*net.OpError{Op=dial} > ... > *os.SyscallError > syscall.Errno(111)
+//
+// Each element names a type passed to [CompilePattern] with [PatternTypeOf], or `_` for any error.
+// Types are matched with the same pointer-value tolerance as [Has], interface types
+// match every error implementing them. An element may constrain the matched value:
+//
+//   - `T(v)` requires the value itself to equal the literal `v`.
+//   - `T{Field=v, Other.Nested=w}` requires the named fields to equal the literals.
+//
+// Literals are integers, floats, booleans, or strings, either bare or double-quoted.
+// Fields of error or [fmt.Stringer] type are compared by their text.
+//
+// The first element may match any node of the tree. A Pattern is safe for concurrent use.
+type Pattern struct {
+	expr  string
+	steps []patternStep
+}
+
+// PatternMatch is the result of a successful [Pattern.Match].
+type PatternMatch struct {
+	nodes []error
+}
+
+// Nodes returns the errors bound to the pattern's elements, in order.
+func (m *PatternMatch) Nodes() []error {
+	return slices.Clone(m.nodes)
+}
+
+// Node returns the error bound to the i-th element of the pattern.
+func (m *PatternMatch) Node(i int) error {
+	return m.nodes[i]
+}
+
+// PatternError is returned by [CompilePattern] for malformed patterns.
+type PatternError struct {
+	Pattern string // The pattern being compiled
+	Msg     string // Description of the problem
+}
+
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("errors: invalid pattern %q: %s", e.Pattern, e.Msg)
+}
+
+// CompilePattern parses a pattern expression. See [Pattern] for the syntax.
+//
+// The types named in the expression are resolved from `types`. Since no global registration is involved,
+// patterns can be compiled in package-level variable initializers.
+func CompilePattern(expr string, types ...PatternType) (*Pattern, error) {
+	names := make(map[string]reflect.Type)
+
+	for _, t := range types {
+		for _, name := range t.names {
+			names[name] = t.typ
+		}
+	}
+
+	steps, err := parsePattern(expr, names)
+	if err != nil {
+		return nil, &PatternError{Pattern: expr, Msg: err.Error()}
+	}
+
+	return &Pattern{expr: expr, steps: steps}, nil
+}
+
+// MustCompilePattern is like [CompilePattern] but panics if the expression cannot be parsed.
+func MustCompilePattern(expr string, types ...PatternType) *Pattern {
+	p, err := CompilePattern(expr, types...)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// String returns the source text used to compile the pattern.
+func (p *Pattern) String() string {
+	return p.expr
+}
+
+// Match reports whether the pattern occurs in `err`'s tree, and returns the bound nodes of the
+// first occurrence in depth-first order.
+func (p *Pattern) Match(err error) (*PatternMatch, bool) {
+	bound := make([]error, len(p.steps))
+
+	for err := range DepthFirstErrorTree(err) {
+		if p.matchAt(err, 0, bound) {
+			return &PatternMatch{nodes: bound}, true
+		}
+	}
+
+	return nil, false
+}
+
+// Matches reports whether the pattern occurs in `err`'s tree.
+func (p *Pattern) Matches(err error) bool {
+	_, ok := p.Match(err)
+
+	return ok
+}
+
+func (p *Pattern) matchAt(err error, i int, bound []error) bool {
+	if !p.steps[i].matches(err) {
+		return false
+	}
+
+	bound[i] = err
+
+	if i == len(p.steps)-1 {
+		return true
+	}
+
+	next := p.steps[i+1]
+
+	if next.descendant {
+		for _, child := range unwrapChildren(err) {
+			for d := range DepthFirstErrorTree(child) {
+				if p.matchAt(d, i+1, bound) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for _, child := range unwrapChildren(err) {
+		if p.matchAt(child, i+1, bound) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unwrapChildren returns the non-nil errors directly wrapped by err.
+func unwrapChildren(err error) []error {
+	var children []error
+
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, child := range x.Unwrap() {
+			if child != nil {
+				children = append(children, child)
+			}
+		}
+
+	case interface{ Unwrap() error }:
+		if child := x.Unwrap(); child != nil {
+			children = []error{child}
+		}
+	}
+
+	return children
+}
+
+// patternStep is a single compiled element of a [Pattern].
+type patternStep struct {
+	typ         reflect.Type // nil matches any error
+	descendant  bool         // whether this step may match below a direct child of the previous one
+	value       *patternConstraint
+	constraints []patternConstraint
+}
+
+// patternConstraint requires the value at a field index path to equal a literal.
+type patternConstraint struct {
+	index   []int
+	want    reflect.Value // compared with [reflect.Value.Equal]
+	text    string        // compared with the Error or String method when textual is set
+	textual bool
+}
+
+func (s *patternStep) matches(err error) bool {
+	v, ok := s.resolve(err)
+	if !ok {
+		return false
+	}
+
+	if s.value != nil && !s.value.matches(v) {
+		return false
+	}
+
+	for i := range s.constraints {
+		if !s.constraints[i].matches(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resolve returns the dereferenced value of err when it has the step's type,
+// or its pointer-value alternate.
+func (s *patternStep) resolve(err error) (reflect.Value, bool) {
+	v := reflect.ValueOf(err)
+
+	switch errType := v.Type(); {
+	case s.typ == nil:
+
+	case s.typ.Kind() == reflect.Interface:
+		if !errType.Implements(s.typ) {
+			return reflect.Value{}, false
+		}
+
+	case errType == s.typ,
+		s.typ.Kind() == reflect.Pointer && errType == s.typ.Elem(),
+		errType.Kind() == reflect.Pointer && errType.Elem() == s.typ:
+
+	default:
+		return reflect.Value{}, false
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, s.typ == nil || s.typ.Kind() == reflect.Interface
+		}
+
+		v = v.Elem()
+	}
+
+	return v, true
+}
+
+func (c *patternConstraint) matches(v reflect.Value) bool {
+	if c.index != nil {
+		var err error
+		if v, err = v.FieldByIndexErr(c.index); err != nil {
+			return false
+		}
+	}
+
+	if !c.textual {
+		return v.Equal(c.want)
+	}
+
+	if v.Kind() == reflect.Interface && v.IsNil() || !v.CanInterface() {
+		return false
+	}
+
+	switch x := v.Interface().(type) {
+	case error:
+		return x.Error() == c.text
+
+	case fmt.Stringer:
+		return x.String() == c.text
+
+	default:
+		return false
+	}
+}
+
+// PatternType names an error type for use in a [Pattern].
+type PatternType struct {
+	names []string
+	typ   reflect.Type
+}
+
+// PatternTypeOf makes the type `T` available to a pattern under the given names,
+// or under its Go syntax representation (e.g., `*net.OpError`) when no name is given.
+//
+// When several types are passed under the same name, the last one wins.
+func PatternTypeOf[T error](names ...string) PatternType {
+	typ := reflect.TypeFor[T]()
+	if len(names) == 0 {
+		names = []string{typ.String()}
+	}
+
+	trimmed := make([]string, len(names))
+	for i, name := range names {
+		trimmed[i] = strings.TrimSpace(name)
+	}
+
+	return PatternType{names: trimmed, typ: typ}
+}
@@ -0,0 +1,334 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	errEmptyPattern     = errors.New("empty pattern")
+	errEmptyElement     = errors.New("empty element")
+	errDanglingEllipsis = errors.New("'...' must be between two elements")
+	errUnbalanced       = errors.New("unbalanced brackets or quotes")
+)
+
+// parsePattern compiles the elements of a pattern expression, resolving type names with types.
+func parsePattern(expr string, types map[string]reflect.Type) ([]patternStep, error) {
+	parts, err := splitTopLevel(expr, '>')
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		steps      []patternStep
+		descendant bool
+	)
+
+	for _, part := range parts {
+		switch part = strings.TrimSpace(part); part {
+		case "":
+			return nil, errEmptyElement
+
+		case "...":
+			if len(steps) == 0 {
+				return nil, errDanglingEllipsis
+			}
+
+			descendant = true
+
+		default:
+			step, err := parseElement(part, types)
+			if err != nil {
+				return nil, err
+			}
+
+			step.descendant = descendant
+			descendant = false
+
+			steps = append(steps, step)
+		}
+	}
+
+	switch {
+	case descendant:
+		return nil, errDanglingEllipsis
+
+	case len(steps) == 0:
+		return nil, errEmptyPattern
+	}
+
+	return steps, nil
+}
+
+// parseElement compiles a single `Type(literal){Field=literal, ...}` element.
+func parseElement(elem string, types map[string]reflect.Type) (patternStep, error) {
+	var step patternStep
+
+	name, rest := elem, ""
+	if i := strings.IndexAny(elem, "({"); i >= 0 {
+		name, rest = elem[:i], elem[i:]
+	}
+
+	if name = strings.TrimSpace(name); name != "_" {
+		typ, ok := types[name]
+		if !ok {
+			return step, fmt.Errorf("unknown type %q, pass it with PatternTypeOf", name)
+		}
+
+		step.typ = typ
+	}
+
+	if rest = strings.TrimSpace(rest); rest == "" {
+		return step, nil
+	}
+
+	base := step.typ
+	if base == nil || base.Kind() == reflect.Interface {
+		return step, fmt.Errorf("element %q: constraints need a concrete type", elem)
+	}
+
+	if base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+
+	if strings.HasPrefix(rest, "(") {
+		end := closingIndex(rest)
+		if end < 0 {
+			return step, errUnbalanced
+		}
+
+		c, err := parseLiteral(strings.TrimSpace(rest[1:end]), base)
+		if err != nil {
+			return step, fmt.Errorf("element %q: %w", elem, err)
+		}
+
+		step.value = &c
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+
+	if rest == "" {
+		return step, nil
+	}
+
+	if !strings.HasPrefix(rest, "{") || closingIndex(rest) != len(rest)-1 {
+		return step, fmt.Errorf("element %q: unexpected %q", elem, rest)
+	}
+
+	fields, err := splitTopLevel(rest[1:len(rest)-1], ',')
+	if err != nil {
+		return step, err
+	}
+
+	for _, field := range fields {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+
+		c, err := parseFieldConstraint(field, base)
+		if err != nil {
+			return step, fmt.Errorf("element %q: %w", elem, err)
+		}
+
+		step.constraints = append(step.constraints, c)
+	}
+
+	return step, nil
+}
+
+// parseFieldConstraint compiles a `Field.Nested=literal` constraint on the struct type base.
+func parseFieldConstraint(field string, base reflect.Type) (patternConstraint, error) {
+	path, lit, ok := strings.Cut(field, "=")
+	if !ok {
+		return patternConstraint{}, fmt.Errorf("constraint %q: missing '='", strings.TrimSpace(field))
+	}
+
+	var index []int
+
+	typ := base
+	for _, name := range strings.Split(strings.TrimSpace(path), ".") {
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() != reflect.Struct {
+			return patternConstraint{}, fmt.Errorf("field %q: %s is not a struct", name, typ)
+		}
+
+		f, ok := typ.FieldByName(name)
+		if !ok {
+			return patternConstraint{}, fmt.Errorf("field %q not found in %s", name, typ)
+		}
+
+		index = append(index, f.Index...)
+		typ = f.Type
+	}
+
+	c, err := parseLiteral(strings.TrimSpace(lit), typ)
+	if err != nil {
+		return c, fmt.Errorf("field %q: %w", strings.TrimSpace(path), err)
+	}
+
+	c.index = index
+
+	return c, nil
+}
+
+var stringerType = reflect.TypeFor[fmt.Stringer]()
+
+// parseLiteral converts a literal to a constraint on values of type typ.
+func parseLiteral(lit string, typ reflect.Type) (patternConstraint, error) {
+	var c patternConstraint
+
+	if strings.HasPrefix(lit, `"`) {
+		s, err := strconv.Unquote(lit)
+		if err != nil {
+			return c, fmt.Errorf("literal %s: %w", lit, err)
+		}
+
+		lit = s
+	}
+
+	want := reflect.New(typ).Elem()
+
+	switch kind := typ.Kind(); kind {
+	case reflect.String:
+		want.SetString(lit)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(lit)
+		if err != nil {
+			return c, fmt.Errorf("literal %q for %s: %w", lit, typ, err)
+		}
+
+		want.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(lit, 0, typ.Bits())
+		if err != nil {
+			return c, fmt.Errorf("literal %q for %s: %w", lit, typ, err)
+		}
+
+		want.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(lit, 0, typ.Bits())
+		if err != nil {
+			return c, fmt.Errorf("literal %q for %s: %w", lit, typ, err)
+		}
+
+		want.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(lit, typ.Bits())
+		if err != nil {
+			return c, fmt.Errorf("literal %q for %s: %w", lit, typ, err)
+		}
+
+		want.SetFloat(f)
+
+	default:
+		if kind != reflect.Interface && !typ.Implements(errorType) && !typ.Implements(stringerType) {
+			return c, fmt.Errorf("unsupported type %s", typ)
+		}
+
+		c.text, c.textual = lit, true
+
+		return c, nil
+	}
+
+	c.want = want
+
+	return c, nil
+}
+
+// splitTopLevel splits s at every sep that is not enclosed in brackets or quotes.
+func splitTopLevel(s string, sep byte) ([]string, error) {
+	var (
+		parts []string
+		depth int
+		quote bool
+		start int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case quote:
+			switch ch {
+			case '\\':
+				i++
+			case '"':
+				quote = false
+			}
+
+		case ch == '"':
+			quote = true
+
+		case ch == '{' || ch == '(':
+			depth++
+
+		case ch == '}' || ch == ')':
+			if depth--; depth < 0 {
+				return nil, errUnbalanced
+			}
+
+		case ch == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	if depth != 0 || quote {
+		return nil, errUnbalanced
+	}
+
+	return append(parts, s[start:]), nil
+}
+
+// closingIndex returns the index of the bracket closing the one at s[0], or -1.
+func closingIndex(s string) int {
+	depth, quote := 0, false
+
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case quote:
+			switch ch {
+			case '\\':
+				i++
+			case '"':
+				quote = false
+			}
+
+		case ch == '"':
+			quote = true
+
+		case ch == '{' || ch == '(':
+			depth++
+
+		case ch == '}' || ch == ')':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+var patternTypes = []PatternType{
+	PatternTypeOf[*net.OpError](),
+	PatternTypeOf[*os.SyscallError](),
+	PatternTypeOf[syscall.Errno](),
+	PatternTypeOf[*MyValueError]("*MyValueError"),
+	PatternTypeOf[MyStructError]("MyStructError"),
+	PatternTypeOf[net.Error]("net.Error"),
+}
+
+// refusedPattern is compiled during package initialization, before any init function runs.
+var refusedPattern = MustCompilePattern("*net.OpError > ... > syscall.Errno", patternTypes...)
+
+func TestPatternMatch(t *testing.T) {
+	t.Parallel()
+
+	sysErr := &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}
+	opErr := &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("wrapped: %w", sysErr)}
+	err := fmt.Errorf("request failed: %w", opErr)
+
+	refused := fmt.Sprintf("syscall.Errno(%d)", int(syscall.ECONNREFUSED))
+
+	tests := []struct {
+		pattern string
+		nodes   []error
+	}{
+		{"*net.OpError{Op=dial} > ... > *os.SyscallError > " + refused, []error{opErr, sysErr, syscall.ECONNREFUSED}},
+		{"*net.OpError{Op=\"dial\", Net=tcp} > ... > " + refused, []error{opErr, syscall.ECONNREFUSED}},
+		{"*os.SyscallError{Syscall=connect, Err=\"" + syscall.ECONNREFUSED.Error() + "\"}", []error{sysErr}},
+		{"net.Error > _ > *os.SyscallError", []error{opErr, opErr.Err, sysErr}},
+		{"*net.OpError > *os.SyscallError", nil},
+		{"*net.OpError{Op=read} > ... > *os.SyscallError", nil},
+		{"*os.SyscallError > ... > *net.OpError", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			m, ok := MustCompilePattern(tt.pattern, patternTypes...).Match(err)
+
+			switch {
+			case ok != (tt.nodes != nil):
+				t.Errorf("Expected match == %t, but got %t", tt.nodes != nil, ok)
+
+			case ok:
+				if got := m.Nodes(); len(got) != len(tt.nodes) {
+					t.Errorf("Expected %d bound nodes, but got %d", len(tt.nodes), len(got))
+				}
+
+				for i, want := range tt.nodes {
+					if got := m.Node(i); got != want {
+						t.Errorf("Expected node %d to be %v, but got %v", i, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPatternMismatch(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("%w, %w",
+		MyStructError{Code: 500},
+		fmt.Errorf("wrapped: %w", MyStructError{Code: 404, Detail: MyDetail{Reason: "gone", Retry: true}}),
+	)
+
+	p := MustCompilePattern("_ > ... > MyStructError{Code=404, Detail.Retry=true}", patternTypes...)
+
+	if m, ok := p.Match(err); !ok {
+		t.Errorf("Expected to match %s, but didn't.", p)
+	} else if e, _ := m.Node(1).(MyStructError); e.Detail.Reason != "gone" {
+		t.Errorf("Expected reason %q, but got %q", "gone", e.Detail.Reason)
+	}
+
+	if !MustCompilePattern("*MyValueError(8)", patternTypes...).Matches(MyValueError(8)) {
+		t.Errorf("Expected *MyValueError(8) to match MyValueError(8), but didn't.")
+	}
+}
+
+func TestCompilePatternError(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range []string{
+		"",
+		"... > *net.OpError",
+		"*net.OpError > ...",
+		"*net.OpError >",
+		"*net.OpError{Op=dial",
+		"*net.UnknownError",
+		"*net.OpError{Missing=1}",
+		"*net.OpError{Op}",
+		"syscall.Errno(abc)",
+		"net.Error{Op=dial}",
+		"_(1)",
+	} {
+		t.Run(pattern, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := CompilePattern(pattern, patternTypes...); err == nil {
+				t.Errorf("Expected an error compiling %q, but got none", pattern)
+			} else if _, ok := HasError[*PatternError](err); !ok {
+				t.Errorf("Expected *PatternError, but got %T", err)
+			}
+		})
+	}
+}
+
+func TestPatternPackageVar(t *testing.T) {
+	t.Parallel()
+
+	sysErr := &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}
+	err := &net.OpError{Op: "dial", Net: "tcp", Err: sysErr}
+
+	if !refusedPattern.Matches(err) {
+		t.Errorf("Expected %s to match, but didn't.", refusedPattern)
+	}
+}
+
+func TestPatternNodesCopy(t *testing.T) {
+	t.Parallel()
+
+	err := MyValueError(8)
+
+	m, ok := MustCompilePattern("*MyValueError", patternTypes...).Match(err)
+	if !ok {
+		t.Fatalf("Expected to match, but didn't.")
+	}
+
+	m.Nodes()[0] = nil
+
+	if m.Node(0) != err {
+		t.Errorf("Expected Nodes to return a copy, but the match was modified")
+	}
+}
+
+func TestPatternUnknownType(t *testing.T) {
+	t.Parallel()
+
+	if _, err := CompilePattern("*net.OpError"); err == nil {
+		t.Errorf("Expected an error for a type not passed to CompilePattern, but got none")
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// PanicError reports a panic in a method of an error in the tree, recovered by [HasSafe],
+// [HasErrorSafe], [AsSafe] or [AsErrorSafe].
+type PanicError struct {
+	Method   string // The name of the panicking method, e.g., "As", "Unwrap" or "Error"
+	Function string // The fully qualified name of the panicking method
+	Value    any    // The value passed to panic
+}
+
+func (e *PanicError) Error() string {
+	if e.Function == "" {
+		return fmt.Sprintf("errors: panic during lookup: %v", e.Value)
+	}
+
+	return fmt.Sprintf("errors: %s panicked: %v", e.Function, e.Value)
+}
+
+// Unwrap returns the value passed to panic, if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+
+	return err
+}
+
+// HasSafe is like [Has], but recovers panics in methods of errors in the tree, such as `As`,
+// `Unwrap` and `Error`, and reports them as a [*PanicError].
+func HasSafe[T error](err error) (result T, ok bool, perr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+
+			result, ok, perr = zero, false, newPanicError(r)
+		}
+	}()
+
+	result, ok = Has[T](err)
+
+	return result, ok, nil
+}
+
+// HasErrorSafe is like [HasError], but recovers panics in methods of errors in the tree, such as `As`,
+// `Unwrap` and `Error`, and reports them as a [*PanicError].
+func HasErrorSafe[T error](err error) (result T, ok bool, perr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+
+			result, ok, perr = zero, false, newPanicError(r)
+		}
+	}()
+
+	result, ok = HasError[T](err)
+
+	return result, ok, nil
+}
+
+// AsSafe is like [As], but recovers panics in methods of errors in the tree, such as `As`,
+// `Unwrap` and `Error`, and reports them as a [*PanicError].
+//
+// AsSafe panics if `target` is a nil pointer.
+func AsSafe[T error](err error, target *T) (ok bool, perr error) {
+	if target == nil {
+		panic("errors: target cannot be nil")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ok, perr = false, newPanicError(r)
+		}
+	}()
+
+	return As(err, target), nil
+}
+
+// AsErrorSafe is like [AsError], but recovers panics in methods of errors in the tree, such as `As`,
+// `Unwrap` and `Error`, and reports them as a [*PanicError].
+//
+// AsErrorSafe panics if `target` is a nil pointer.
+func AsErrorSafe[T error](err error, target *T) (ok bool, perr error) {
+	if target == nil {
+		panic("errors: target cannot be nil")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ok, perr = false, newPanicError(r)
+		}
+	}()
+
+	return AsError(err, target), nil
+}
+
+// newPanicError creates a [*PanicError] for a recovered value. It must be called from the deferred
+// function that recovered, so the panicking frames are still on the stack.
+func newPanicError(r any) *PanicError {
+	e := &PanicError{Value: r}
+
+	var pcs [64]uintptr
+
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	// The stack reads: runtime.gopanic, other runtime frames (e.g., for nil dereferences),
+	// the frames of user code, and finally the frames of this package that called it.
+	// User frames are attributed to the innermost error method, since an `As` method may call
+	// `Error` or helper functions, falling back to the outermost user frame.
+	var panicking, user, method bool
+
+	for {
+		frame, more := frames.Next()
+
+		switch {
+		case !panicking:
+			panicking = frame.Function == "runtime.gopanic"
+
+		case strings.HasPrefix(frame.Function, "runtime."):
+
+		case strings.HasPrefix(frame.Function, packagePrefix):
+			if user {
+				e.Method = e.Function[strings.LastIndexByte(e.Function, '.')+1:]
+
+				return e
+			}
+
+		case !method:
+			user = true
+			e.Function = frame.Function
+
+			switch frame.Function[strings.LastIndexByte(frame.Function, '.')+1:] {
+			case "As", "Unwrap", "Error":
+				method = true
+			}
+		}
+
+		if !more {
+			e.Function = ""
+
+			return e
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestHasSafe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		function string
+		method   string
+	}{
+		{"As", fmt.Errorf("wrapped: %w", MyPanicAsError{}), "fillmore-labs.com/exp/errors_test.MyPanicAsError.As", "As"},
+		{"Unwrap", fmt.Errorf("wrapped: %w", MyPanicUnwrapError{}), "fillmore-labs.com/exp/errors_test.MyPanicUnwrapError.Unwrap", "Unwrap"},
+		{"Error", fmt.Errorf("wrapped: %w", MyPanicMessageError{}), "fillmore-labs.com/exp/errors_test.MyPanicMessageError.Error", "Error"},
+		{"nil receiver", (*MyPointerError)(nil), "fillmore-labs.com/exp/errors_test.(*MyPointerError).As", "As"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			check := func(t *testing.T, perr error) {
+				t.Helper()
+
+				p, ok := HasError[*PanicError](perr)
+				if !ok {
+					t.Fatalf("Expected *PanicError, but got %v", perr)
+				}
+
+				if p.Function != tt.function || p.Method != tt.method {
+					t.Errorf("Expected panic in %s (%s), but got %s (%s)", tt.function, tt.method, p.Function, p.Method)
+				}
+			}
+
+			_, ok, perr := HasSafe[*MyValueError](tt.err)
+			if ok {
+				t.Errorf("Expected to not find *MyValueError, but did.")
+			}
+
+			check(t, perr)
+
+			_, _, perr = HasErrorSafe[*MyValueError](tt.err)
+			check(t, perr)
+
+			var target *MyValueError
+
+			_, perr = AsSafe(tt.err, &target)
+			check(t, perr)
+
+			_, perr = AsErrorSafe(tt.err, &target)
+			check(t, perr)
+		})
+	}
+}
+
+func TestHasSafeNoPanic(t *testing.T) {
+	t.Parallel()
+
+	if e, ok, perr := HasSafe[*MyValueError](MyValueError(8)); !ok || perr != nil {
+		t.Errorf("Expected to find *MyValueError without panic, but got %t, %v", ok, perr)
+	} else if *e != MyValueError(8) {
+		t.Errorf("Expected *MyValueError(8), but got %d", int(*e))
+	}
+}
+
+func TestPanicErrorUnwrap(t *testing.T) {
+	t.Parallel()
+
+	_, _, perr := HasSafe[*MyValueError](MyPanicUnwrapError{})
+	if !errors.Is(perr, errUnwrapPanic) {
+		t.Errorf("Expected to unwrap the panic value, but got %v", perr)
+	}
+}
+
+func TestAsMethodValidation(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Join(MyLyingAsError{}, &MyPointerOnlyError{v: 8})
+
+	if e, ok := Has[*MyPointerOnlyError](err); !ok || e == nil || e.v != 8 {
+		t.Errorf("Expected to skip the nil result and find *MyPointerOnlyError, but got %v", e)
+	}
+
+	if e, ok := HasError[*MyPointerOnlyError](err); !ok || e == nil || e.v != 8 {
+		t.Errorf("Expected to skip the nil result and find *MyPointerOnlyError, but got %v", e)
+	}
+
+	previous := &MyPointerOnlyError{v: 1}
+
+	target := previous
+	if As(MyLyingAsError{}, &target) || target != previous {
+		t.Errorf("Expected As to restore the target, but got %v", target)
+	}
+
+	target = previous
+	if AsError(MyLyingAsError{}, &target) || target != previous {
+		t.Errorf("Expected AsError to restore the target, but got %v", target)
+	}
+}
+
+var errUnwrapPanic = errors.New("unwrap panic")
+
+type (
+	MyPanicAsError      struct{}
+	MyPanicUnwrapError  struct{}
+	MyPanicMessageError struct{}
+	MyLyingAsError      struct{}
+)
+
+func (MyPanicAsError) Error() string { return "MyPanicAsError" }
+
+func (MyPanicAsError) As(target any) bool {
+	_ = target.(*int) // Panics deliberately
+
+	return true
+}
+
+func (MyPanicUnwrapError) Error() string { return "MyPanicUnwrapError" }
+
+func (MyPanicUnwrapError) Unwrap() error { panic(errUnwrapPanic) }
+
+func (MyPanicMessageError) Error() string { panic("message panic") }
+
+// As matches by message, calling the panicking Error method.
+func (e MyPanicMessageError) As(any) bool {
+	return strings.HasPrefix(e.Error(), "MyValueError")
+}
+
+func (MyLyingAsError) Error() string { return "MyLyingAsError" }
+
+// As claims success, but sets nil.
+func (MyLyingAsError) As(target any) bool {
+	if t, ok := target.(**MyPointerOnlyError); ok {
+		*t = nil
+
+		return true
+	}
+
+	return false
+}
+
+var _, _, _ error = MyPanicAsError{}, MyPanicUnwrapError{}, MyLyingAsError{}
@@ -0,0 +1,107 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import "reflect"
+
+// SentinelSet checks errors against many sentinel errors in a single traversal of an error tree.
+//
+// Comparable sentinels are looked up by hashing, so the cost per error in the tree does not grow
+// with the number of sentinels, except for errors with an `Is(error) bool` method.
+// A SentinelSet is safe for concurrent use.
+type SentinelSet struct {
+	sentinels []error
+	types     map[reflect.Type]struct{} // Dynamic types of the indexed sentinels
+	index     map[error]int
+}
+
+// NewSentinelSet returns a [SentinelSet] for the given sentinels. Nil sentinels are ignored.
+func NewSentinelSet(sentinels ...error) *SentinelSet {
+	s := &SentinelSet{
+		sentinels: make([]error, 0, len(sentinels)),
+		types:     make(map[reflect.Type]struct{}),
+		index:     make(map[error]int, len(sentinels)),
+	}
+
+	for _, sentinel := range sentinels {
+		if sentinel == nil {
+			continue
+		}
+
+		if typ := reflect.TypeOf(sentinel); typ.Comparable() {
+			i, ok := s.lookup(sentinel)
+			if ok {
+				continue
+			}
+
+			if i < 0 {
+				// Comparable type holding a non-comparable value, keep it for Is methods only.
+				s.sentinels = append(s.sentinels, sentinel)
+
+				continue
+			}
+
+			s.types[typ] = struct{}{}
+			s.index[sentinel] = len(s.sentinels)
+		}
+
+		s.sentinels = append(s.sentinels, sentinel)
+	}
+
+	return s
+}
+
+// IsAny reports whether any error in `err`'s tree matches a sentinel of the set, and returns
+// the first matched sentinel.
+//
+// An error matches a sentinel if it is equal to it or if it implements a method `Is(error) bool`
+// such that `Is(sentinel)` returns true, like [errors.Is] defines it. The tree is traversed
+// depth-first; for an error matching several sentinels, equality is preferred, followed by
+// the order in which the sentinels were given.
+func (s *SentinelSet) IsAny(err error) (matched error, ok bool) {
+	for err := range DepthFirstErrorTree(err) {
+		if _, ok := s.types[reflect.TypeOf(err)]; ok {
+			if i, ok := s.lookup(err); ok {
+				return s.sentinels[i], true
+			}
+		}
+
+		if x, ok := err.(interface{ Is(error) bool }); ok {
+			for _, sentinel := range s.sentinels {
+				if x.Is(sentinel) {
+					return sentinel, true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// lookup returns the index of the sentinel equal to err. A comparable type can hold
+// non-comparable values in interface fields; hashing these panics, and lookup returns -1.
+func (s *SentinelSet) lookup(err error) (i int, ok bool) {
+	defer func() {
+		if recover() != nil {
+			i, ok = -1, false
+		}
+	}()
+
+	i, ok = s.index[err]
+
+	return i, ok
+}
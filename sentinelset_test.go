@@ -0,0 +1,152 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestSentinelSet(t *testing.T) {
+	t.Parallel()
+
+	errOther := errors.New("other")
+	set := NewSentinelSet(io.EOF, nil, io.ErrUnexpectedEOF, net.ErrClosed, fs.ErrNotExist, io.EOF)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"nil", nil, nil},
+		{"root", io.EOF, io.EOF},
+		{"wrapped", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), io.ErrUnexpectedEOF},
+		{"joined", errors.Join(errOther, fmt.Errorf("conn: %w", net.ErrClosed)), net.ErrClosed},
+		{"is method", MySentinelError{}, fs.ErrNotExist},
+		{"non-comparable", MyNonComparableError{}, nil},
+		{"missing", errOther, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := set.IsAny(tt.err)
+
+			if ok != (tt.want != nil) {
+				t.Errorf("Expected match == %t, but got %t", tt.want != nil, ok)
+			} else if got != tt.want {
+				t.Errorf("Expected %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSentinelSetNonComparableValue(t *testing.T) {
+	t.Parallel()
+
+	nonComparable := MyWrapperError{inner: MyNonComparableError{}}
+
+	tests := []struct {
+		name string
+		set  *SentinelSet
+		err  error
+	}{
+		{"other type", NewSentinelSet(io.EOF), nonComparable},
+		{"same type", NewSentinelSet(MyWrapperError{inner: io.EOF}), nonComparable},
+		{"non-comparable sentinel", NewSentinelSet(nonComparable), MyWrapperError{inner: io.EOF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, ok := tt.set.IsAny(tt.err); ok {
+				t.Errorf("Expected no match, but got one")
+			}
+		})
+	}
+}
+
+type (
+	MySentinelError      struct{}
+	MyNonComparableError struct{ _ []int }
+	MyWrapperError       struct{ inner error }
+)
+
+func (MySentinelError) Error() string { return "MySentinelError" }
+
+func (MySentinelError) Is(target error) bool { return target == os.ErrNotExist }
+
+func (MyNonComparableError) Error() string { return "MyNonComparableError" }
+
+func (MyWrapperError) Error() string { return "MyWrapperError" }
+
+var _, _, _ error = MySentinelError{}, MyNonComparableError{}, MyWrapperError{}
+
+var benchSentinels = func() []error {
+	sentinels := []error{io.EOF, io.ErrUnexpectedEOF, net.ErrClosed, fs.ErrNotExist, fs.ErrPermission}
+	for i := range 40 {
+		sentinels = append(sentinels, fmt.Errorf("sentinel %d", i))
+	}
+
+	return sentinels
+}()
+
+var benchSentinelErr = fmt.Errorf("request: %w", errors.Join(
+	errors.New("first"),
+	fmt.Errorf("handler: %w", fmt.Errorf("conn: %w", benchSentinels[len(benchSentinels)-1])),
+))
+
+func BenchmarkSentinelSet(b *testing.B) {
+	set := NewSentinelSet(benchSentinels...)
+
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, ok := set.IsAny(benchSentinelErr); !ok {
+			b.Fatal("Expected to find sentinel")
+		}
+	}
+}
+
+func BenchmarkErrorsIsRepeated(b *testing.B) {
+	b.ReportAllocs()
+
+	for range b.N {
+		var found bool
+
+		for _, sentinel := range benchSentinels {
+			if errors.Is(benchSentinelErr, sentinel) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			b.Fatal("Expected to find sentinel")
+		}
+	}
+}
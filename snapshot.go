@@ -0,0 +1,167 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Snapshot captures an error tree for repeated lookups with stable results.
+//
+// When [Has] finds a value `MyError` for a queried pointer type `*MyError`, it allocates a new pointer
+// on every call. Lookups through a Snapshot memoize these synthesized results, as well as the results of
+// `As(any) bool` methods, per error in the tree, so repeated lookups return the same pointer, which can be
+// compared with `==` or used as a map key.
+//
+// The tree is traversed once when the snapshot is created. A Snapshot is safe for concurrent use.
+type Snapshot struct {
+	err   error
+	nodes []error // The errors of the tree in depth-first order
+
+	mu      sync.Mutex
+	results map[snapshotKey]snapshotEntry
+}
+
+// snapshotKey identifies a synthesized result for a queried type at a position in the tree.
+type snapshotKey struct {
+	index int
+	typ   reflect.Type
+}
+
+// snapshotEntry is a memoized result and the strategy that produced it.
+type snapshotEntry struct {
+	result   any
+	strategy Strategy
+}
+
+// NewSnapshot captures the tree of `err`.
+func NewSnapshot(err error) *Snapshot {
+	return &Snapshot{
+		err:     err,
+		nodes:   slices.Collect(DepthFirstErrorTree(err)),
+		results: make(map[snapshotKey]snapshotEntry),
+	}
+}
+
+// Err returns the root of the captured tree.
+func (s *Snapshot) Err() error {
+	return s.err
+}
+
+// SnapshotHas is like [Has], but returns the same result for repeated lookups on the same snapshot,
+// including pointers synthesized for pointer-value mismatches and results of `As(any) bool` methods.
+func SnapshotHas[T error](s *Snapshot) (T, bool) {
+	var (
+		handler = altHandlerFor[T]()
+		ptr     *T
+	)
+
+	for i, err := range s.nodes {
+		if target, ok := err.(T); ok {
+			return target, true
+		}
+
+		if handler.matchAssert(err) {
+			observeMismatch[T](err, StrategyAlternate)
+
+			result, _, ok := snapshotResult(s, i, func() (T, Strategy, bool) {
+				result, ok := handler.handleAssert(err)
+
+				return result, StrategyAlternate, ok
+			})
+
+			return result, ok
+		}
+
+		if x, ok := err.(interface{ As(any) bool }); ok {
+			result, strategy, ok := snapshotResult(s, i, func() (T, Strategy, bool) {
+				if ptr == nil {
+					ptr = new(T)
+				}
+
+				if result, ok := callAs(x, ptr); ok {
+					return result, StrategyAs, true
+				}
+
+				result, ok := handler.handleAs(x)
+
+				return result, StrategyAsAlternate, ok
+			})
+			if ok {
+				if strategy == StrategyAsAlternate {
+					observeMismatch[T](err, StrategyAsAlternate)
+				}
+
+				return result, true
+			}
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// SnapshotAs is like [As], but sets target to the same result for repeated lookups on the same
+// snapshot, see [SnapshotHas].
+//
+// SnapshotAs panics if `target` is a nil pointer.
+func SnapshotAs[T error](s *Snapshot, target *T) bool {
+	if target == nil {
+		panic("errors: target cannot be nil")
+	}
+
+	result, ok := SnapshotHas[T](s)
+	if ok {
+		*target = result
+	}
+
+	return ok
+}
+
+// snapshotResult returns the memoized result for the i-th error of the snapshot, calling create on first use.
+// create runs without holding the lock, since it may call methods of errors in the tree. When lookups race,
+// the first stored result wins.
+func snapshotResult[T error](s *Snapshot, i int, create func() (T, Strategy, bool)) (T, Strategy, bool) {
+	key := snapshotKey{index: i, typ: reflect.TypeFor[T]()}
+
+	s.mu.Lock()
+	entry, ok := s.results[key]
+	s.mu.Unlock()
+
+	if ok {
+		return entry.result.(T), entry.strategy, true
+	}
+
+	result, strategy, ok := create()
+	if !ok {
+		return result, strategy, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.results[key]; ok {
+		return entry.result.(T), entry.strategy, true
+	}
+
+	s.results[key] = snapshotEntry{result: result, strategy: strategy}
+
+	return result, strategy, true
+}
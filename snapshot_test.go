@@ -0,0 +1,134 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", MyValueError(8))
+	s := NewSnapshot(err)
+
+	if s.Err() != err {
+		t.Errorf("Expected snapshot of %v, but got %v", err, s.Err())
+	}
+
+	e1, ok1 := SnapshotHas[*MyValueError](s)
+	e2, ok2 := SnapshotHas[*MyValueError](s)
+
+	if !ok1 || !ok2 {
+		t.Fatalf("Expected to find *MyValueError, but didn't.")
+	}
+
+	if e1 != e2 {
+		t.Errorf("Expected the same pointer for repeated lookups, but got %p and %p", e1, e2)
+	}
+
+	if *e1 != MyValueError(8) {
+		t.Errorf("Expected *MyValueError(8), but got %d", int(*e1))
+	}
+
+	var e3 *MyValueError
+	if !SnapshotAs(s, &e3) || e3 != e1 {
+		t.Errorf("Expected SnapshotAs to return %p, but got %p", e1, e3)
+	}
+
+	if e4, _ := SnapshotHas[*MyValueError](NewSnapshot(err)); e4 == e1 {
+		t.Errorf("Expected a different pointer for a different snapshot")
+	}
+
+	if _, ok := SnapshotHas[*MyPointerOnlyError](s); ok {
+		t.Errorf("Expected to not find *MyPointerOnlyError, but did.")
+	}
+}
+
+func TestSnapshotAs(t *testing.T) {
+	t.Parallel()
+
+	s := NewSnapshot(fmt.Errorf("wrapped: %w", MyAsError(8)))
+
+	e1, ok1 := SnapshotHas[*MyValueError](s)
+	e2, ok2 := SnapshotHas[*MyValueError](s)
+
+	if !ok1 || !ok2 {
+		t.Fatalf("Expected to find *MyValueError via As, but didn't.")
+	}
+
+	if e1 != e2 {
+		t.Errorf("Expected the same pointer for repeated lookups, but got %p and %p", e1, e2)
+	}
+}
+
+func TestSnapshotAsMethod(t *testing.T) {
+	t.Parallel()
+
+	// MyAsError allocates a new *MyPointerError on every call of its As method.
+	s := NewSnapshot(fmt.Errorf("wrapped: %w", MyAsError(8)))
+
+	e1, ok1 := SnapshotHas[*MyPointerError](s)
+	e2, ok2 := SnapshotHas[*MyPointerError](s)
+
+	if !ok1 || !ok2 {
+		t.Fatalf("Expected to find *MyPointerError via As, but didn't.")
+	}
+
+	if e1 != e2 {
+		t.Errorf("Expected the same pointer for repeated lookups, but got %p and %p", e1, e2)
+	}
+}
+
+// reentrantAsError looks up another type in its snapshot from its As method.
+type reentrantAsError struct{ s *Snapshot }
+
+func (e *reentrantAsError) Error() string { return "reentrant" }
+
+func (e *reentrantAsError) As(target any) bool {
+	t, ok := target.(**MyPointerError)
+	if !ok {
+		return false
+	}
+
+	v, ok := SnapshotHas[*MyValueError](e.s)
+	if !ok {
+		return false
+	}
+
+	p := MyPointerError(*v)
+	*t = &p
+
+	return true
+}
+
+func TestSnapshotReentrant(t *testing.T) {
+	t.Parallel()
+
+	e := &reentrantAsError{}
+	s := NewSnapshot(errors.Join(e, MyValueError(8)))
+	e.s = s
+
+	// The As method is called through the alternate form *MyPointerError.
+	if p, ok := SnapshotHas[MyPointerError](s); !ok || p != MyPointerError(8) {
+		t.Errorf("Expected to find MyPointerError(8) via a reentrant As, but got %v", p)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// SetType describes an error type registered in a [TypeSet]. It is created by [SetTypeOf].
+type SetType interface {
+	// Type returns the registered type.
+	Type() reflect.Type
+
+	// convert returns node as the registered type, given how its dynamic type matched.
+	convert(node error, kind MatchKind) (error, bool)
+
+	// as tries node's As method with the registered type and its alternate form.
+	as(x interface{ As(any) bool }) (error, bool)
+}
+
+// SetTypeOf returns the [SetType] for `T`.
+func SetTypeOf[T error]() SetType {
+	return setType[T]{}
+}
+
+type setType[T error] struct{}
+
+func (setType[T]) Type() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (setType[T]) convert(node error, kind MatchKind) (error, bool) {
+	if kind == MatchDirect {
+		result, ok := node.(T)
+
+		return result, ok
+	}
+
+	result, ok := altHandlerFor[T]().handleAssert(node)
+	if ok {
+		observeMismatch[T](node, StrategyAlternate)
+	}
+
+	return result, ok
+}
+
+func (setType[T]) as(x interface{ As(any) bool }) (error, bool) {
+	if target, ok := callAs(x, new(T)); ok {
+		return target, true
+	}
+
+	result, ok := altHandlerFor[T]().handleAs(x)
+	if ok {
+		if node, isErr := x.(error); isErr {
+			observeMismatch[T](node, StrategyAsAlternate)
+		}
+	}
+
+	return result, ok
+}
+
+// MatchKind describes how an error in the tree matched a type of a [TypeSet].
+type MatchKind uint8
+
+const (
+	// MatchDirect means the error's value is assignable to the type.
+	MatchDirect MatchKind = iota + 1
+	// MatchAlternate means the error is the pointer-value alternate form of the type.
+	MatchAlternate
+	// MatchAs means the error's `As(any) bool` method returned true for the type or its alternate form.
+	MatchAs
+)
+
+// String returns the name of the match kind.
+func (k MatchKind) String() string {
+	switch k {
+	case MatchDirect:
+		return "direct"
+	case MatchAlternate:
+		return "alternate"
+	case MatchAs:
+		return "As"
+	default:
+		return "none"
+	}
+}
+
+// TypeMatch reports a match of an error in the tree against a type of a [TypeSet].
+type TypeMatch struct {
+	Index int       // Index of the type in the set
+	Node  error     // The error in the tree
+	Value error     // The error converted to the matched type
+	Kind  MatchKind // How the error matched
+}
+
+// TypeSet matches errors against many types in a single traversal of an error tree.
+//
+// Errors have a type if [Has] would find them for that type. The per-type decisions are computed
+// once for each dynamic error type encountered and reused afterward.
+// A TypeSet is safe for concurrent use.
+type TypeSet struct {
+	types []SetType
+	hits  sync.Map // reflect.Type -> []typeHit
+}
+
+// typeHit is a precomputed match of a dynamic error type against a set type.
+type typeHit struct {
+	index int
+	kind  MatchKind
+}
+
+// NewTypeSet returns a [TypeSet] for the given types.
+func NewTypeSet(types ...SetType) *TypeSet {
+	return &TypeSet{types: types}
+}
+
+// Len returns the number of types in the set.
+func (s *TypeSet) Len() int {
+	return len(s.types)
+}
+
+// Type returns the i-th type of the set.
+func (s *TypeSet) Type(i int) reflect.Type {
+	return s.types[i].Type()
+}
+
+// Matches returns all matches in `err`'s tree, in depth-first order of the errors
+// and in the order of the set's types for each error.
+func (s *TypeSet) Matches(err error) []TypeMatch {
+	var matches []TypeMatch
+
+	s.scan(err, func(m TypeMatch) bool {
+		matches = append(matches, m)
+
+		return true
+	})
+
+	return matches
+}
+
+// First returns the first match in `err`'s tree, in the order of [TypeSet.Matches].
+func (s *TypeSet) First(err error) (TypeMatch, bool) {
+	var (
+		first TypeMatch
+		found bool
+	)
+
+	s.scan(err, func(m TypeMatch) bool {
+		first, found = m, true
+
+		return false
+	})
+
+	return first, found
+}
+
+// Find returns, for each type of the set, the first match in `err`'s tree, as [Has] would.
+// Types that are not found have a zero [TypeMatch].
+func (s *TypeSet) Find(err error) []TypeMatch {
+	found, remaining := make([]TypeMatch, len(s.types)), len(s.types)
+
+	s.scan(err, func(m TypeMatch) bool {
+		if found[m.Index].Kind == 0 {
+			found[m.Index] = m
+			remaining--
+		}
+
+		return remaining > 0
+	})
+
+	return found
+}
+
+func (s *TypeSet) scan(err error, yield func(TypeMatch) bool) {
+	var pending []TypeMatch // Matches of the current node, ordered by type index
+
+	for node := range DepthFirstErrorTree(err) {
+		pending = pending[:0]
+
+		for _, hit := range s.typeHits(reflect.TypeOf(node)) {
+			if value, ok := s.types[hit.index].convert(node, hit.kind); ok {
+				pending = append(pending, TypeMatch{Index: hit.index, Node: node, Value: value, Kind: hit.kind})
+			}
+		}
+
+		if x, ok := node.(interface{ As(any) bool }); ok {
+			pending = s.appendAs(pending, node, x)
+		}
+
+		for _, m := range pending {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+// appendAs adds the matches through x's As method for types not already in pending,
+// keeping pending ordered by type index.
+func (s *TypeSet) appendAs(pending []TypeMatch, node error, x interface{ As(any) bool }) []TypeMatch {
+	direct := len(pending)
+
+	for i, t := range s.types {
+		if slices.ContainsFunc(pending[:direct], func(m TypeMatch) bool { return m.Index == i }) {
+			continue
+		}
+
+		if value, ok := t.as(x); ok {
+			pending = append(pending, TypeMatch{Index: i, Node: node, Value: value, Kind: MatchAs})
+		}
+	}
+
+	if direct > 0 && len(pending) > direct {
+		slices.SortStableFunc(pending, func(a, b TypeMatch) int { return a.Index - b.Index })
+	}
+
+	return pending
+}
+
+// typeHits returns the precomputed direct and alternate matches for a dynamic error type.
+func (s *TypeSet) typeHits(errType reflect.Type) []typeHit {
+	if hits, ok := s.hits.Load(errType); ok {
+		return hits.([]typeHit)
+	}
+
+	var hits []typeHit
+
+	for i, t := range s.types {
+		typ := t.Type()
+
+		switch {
+		case typ == errType, typ.Kind() == reflect.Interface && errType.Implements(typ):
+			hits = append(hits, typeHit{index: i, kind: MatchDirect})
+
+		case typ.Kind() == reflect.Pointer && typ.Elem() == errType,
+			errType.Kind() == reflect.Pointer && errType.Elem() == typ:
+			hits = append(hits, typeHit{index: i, kind: MatchAlternate})
+		}
+	}
+
+	actual, _ := s.hits.LoadOrStore(errType, hits)
+
+	return actual.([]typeHit)
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	. "fillmore-labs.com/exp/errors"
+)
+
+func TestTypeSet(t *testing.T) {
+	t.Parallel()
+
+	pointerErr := MyPointerError(2)
+	err := errors.Join(
+		MyValueError(1),
+		fmt.Errorf("wrapped: %w", &pointerErr),
+		MyAsError(3),
+	)
+
+	set := NewTypeSet(
+		SetTypeOf[*MyValueError](),
+		SetTypeOf[MyPointerError](),
+		SetTypeOf[*fs.PathError](),
+		SetTypeOf[interface {
+			error
+			As(any) bool
+		}](),
+	)
+
+	type match struct {
+		index int
+		node  error
+		kind  MatchKind
+	}
+
+	want := []match{
+		{0, MyValueError(1), MatchAlternate},
+		{1, &pointerErr, MatchAlternate},
+		{3, &pointerErr, MatchDirect},
+		{0, MyAsError(3), MatchAs},
+		{1, MyAsError(3), MatchAs},
+		{3, MyAsError(3), MatchDirect},
+	}
+
+	got := set.Matches(err)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d matches, but got %d: %v", len(want), len(got), got)
+	}
+
+	for i, w := range want {
+		if g := got[i]; g.Index != w.index || g.Node != w.node || g.Kind != w.kind {
+			t.Errorf("Expected match %d to be %d/%v/%s, but got %d/%v/%s", i, w.index, w.node, w.kind, g.Index, g.Node, g.Kind)
+		}
+	}
+
+	if v, ok := got[0].Value.(*MyValueError); !ok || *v != MyValueError(1) {
+		t.Errorf("Expected value *MyValueError(1), but got %#v", got[0].Value)
+	}
+
+	if v, ok := got[4].Value.(MyPointerError); !ok || v != MyPointerError(3) {
+		t.Errorf("Expected value MyPointerError(3), but got %#v", got[4].Value)
+	}
+
+	if first, ok := set.First(err); !ok || first.Index != 0 {
+		t.Errorf("Expected first match for type 0, but got %v", first)
+	}
+
+	found := set.Find(err)
+	if found[1].Node != &pointerErr || found[2].Kind != 0 {
+		t.Errorf("Expected first *MyPointerError and no *fs.PathError, but got %v", found)
+	}
+}
+
+type numberedError[N any] struct{}
+
+func (numberedError[N]) Error() string { return fmt.Sprintf("numberedError[%T]", *new(N)) }
+
+func hasType[T error](err error) bool {
+	_, ok := Has[T](err)
+
+	return ok
+}
+
+var (
+	benchSetTypes = []SetType{
+		SetTypeOf[numberedError[[0]int]](), SetTypeOf[numberedError[[1]int]](), SetTypeOf[numberedError[[2]int]](),
+		SetTypeOf[numberedError[[3]int]](), SetTypeOf[numberedError[[4]int]](), SetTypeOf[numberedError[[5]int]](),
+		SetTypeOf[numberedError[[6]int]](), SetTypeOf[numberedError[[7]int]](), SetTypeOf[numberedError[[8]int]](),
+		SetTypeOf[numberedError[[9]int]](), SetTypeOf[numberedError[[10]int]](), SetTypeOf[numberedError[[11]int]](),
+		SetTypeOf[numberedError[[12]int]](), SetTypeOf[numberedError[[13]int]](), SetTypeOf[numberedError[[14]int]](),
+		SetTypeOf[numberedError[[15]int]](), SetTypeOf[numberedError[[16]int]](), SetTypeOf[numberedError[[17]int]](),
+		SetTypeOf[numberedError[[18]int]](), SetTypeOf[numberedError[[19]int]](), SetTypeOf[numberedError[[20]int]](),
+		SetTypeOf[numberedError[[21]int]](), SetTypeOf[numberedError[[22]int]](), SetTypeOf[numberedError[[23]int]](),
+		SetTypeOf[numberedError[[24]int]](), SetTypeOf[numberedError[[25]int]](), SetTypeOf[numberedError[[26]int]](),
+		SetTypeOf[numberedError[[27]int]](), SetTypeOf[*fs.PathError](), SetTypeOf[*MyValueError](),
+	}
+
+	benchHasFuncs = []func(error) bool{
+		hasType[numberedError[[0]int]], hasType[numberedError[[1]int]], hasType[numberedError[[2]int]],
+		hasType[numberedError[[3]int]], hasType[numberedError[[4]int]], hasType[numberedError[[5]int]],
+		hasType[numberedError[[6]int]], hasType[numberedError[[7]int]], hasType[numberedError[[8]int]],
+		hasType[numberedError[[9]int]], hasType[numberedError[[10]int]], hasType[numberedError[[11]int]],
+		hasType[numberedError[[12]int]], hasType[numberedError[[13]int]], hasType[numberedError[[14]int]],
+		hasType[numberedError[[15]int]], hasType[numberedError[[16]int]], hasType[numberedError[[17]int]],
+		hasType[numberedError[[18]int]], hasType[numberedError[[19]int]], hasType[numberedError[[20]int]],
+		hasType[numberedError[[21]int]], hasType[numberedError[[22]int]], hasType[numberedError[[23]int]],
+		hasType[numberedError[[24]int]], hasType[numberedError[[25]int]], hasType[numberedError[[26]int]],
+		hasType[numberedError[[27]int]], hasType[*fs.PathError], hasType[*MyValueError],
+	}
+
+	benchSetErr = fmt.Errorf("request: %w", errors.Join(
+		fmt.Errorf("handler: %w", numberedError[[20]int]{}),
+		&fs.PathError{Op: "open", Path: "/a", Err: fs.ErrNotExist},
+		MyValueError(8),
+	))
+)
+
+func BenchmarkTypeSet(b *testing.B) {
+	set := NewTypeSet(benchSetTypes...)
+
+	b.ReportAllocs()
+
+	for range b.N {
+		if found := set.Find(benchSetErr); found[20].Kind == 0 {
+			b.Fatal("Expected to find numberedError[[20]int]")
+		}
+	}
+}
+
+func BenchmarkHasRepeated(b *testing.B) {
+	b.ReportAllocs()
+
+	for range b.N {
+		var found [30]bool
+		for i, has := range benchHasFuncs {
+			found[i] = has(benchSetErr)
+		}
+
+		if !found[20] {
+			b.Fatal("Expected to find numberedError[[20]int]")
+		}
+	}
+}